@@ -0,0 +1,225 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"testing"
+
+	"github.com/deepmap/oapi-codegen/pkg/testutil"
+	"github.com/opbls/scapo/config"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestCompatV1 pins v1 behaviour, which clients of / and /v1 rely on.
+// Do not change expectations here, make the change in a new version instead.
+func TestCompatV1(t *testing.T) {
+	for _, base := range []string{"", "/v1"} {
+		t.Run("base="+base, func(t *testing.T) {
+			testCompatV1(t, base)
+		})
+	}
+}
+
+func testCompatV1(t *testing.T, base string) {
+	// database
+	db := setupDB(t)
+	defer db.Close()
+
+	// handlers
+	cfg := config.Default()
+	cfg.ResponseValidation = "fail"
+	cfg.V1Sunset = "2027-04-18"
+	r, err := newRouter(db, &cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ids := []float64{}
+	for _, name := range []string{"foo", "bar", "baz"} {
+		rr := testutil.NewRequest().Post(base+"/pets").WithJsonBody(map[string]interface{}{"name": name, "tag": "t"}).GoWithHTTPHandler(t, r).Recorder
+		assert.Equal(t, http.StatusOK, rr.Code)
+		pet := decodeObject(t, rr.Body.Bytes())
+		assert.Equal(t, []string{"id", "name", "tag"}, keys(pet))
+		ids = append(ids, pet["id"].(float64))
+	}
+
+	t.Run("SUCCESS_FindPets_Array", func(t *testing.T) {
+		rr := doGet(t, r, base+"/pets?limit=2")
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Equal(t, "application/json", rr.Header().Get("Content-Type"))
+		assert.Equal(t, "@1792281600", rr.Header().Get("Deprecation"))
+		assert.Equal(t, "Sun, 18 Apr 2027 00:00:00 GMT", rr.Header().Get("Sunset"))
+		assert.NotEmpty(t, rr.Header().Get("X-Next-Cursor"))
+		assert.Contains(t, rr.Header().Get("Link"), `rel="next"`)
+
+		pets := []map[string]interface{}{}
+		err := json.Unmarshal(rr.Body.Bytes(), &pets)
+		if assert.NoError(t, err, "v1 FindPets returns a bare array") {
+			assert.Equal(t, 2, len(pets))
+			assert.Equal(t, []string{"id", "name", "tag"}, keys(pets[0]))
+		}
+	})
+
+	t.Run("SUCCESS_FindPetById", func(t *testing.T) {
+		rr := doGet(t, r, fmt.Sprintf("%s/pets/%d", base, int(ids[0])))
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.JSONEq(t, fmt.Sprintf(`{"id":%d,"name":"foo","tag":"t"}`, int(ids[0])), rr.Body.String())
+	})
+
+	t.Run("SUCCESS_UpdatePet", func(t *testing.T) {
+		url := fmt.Sprintf("%s/pets/%d", base, int(ids[1]))
+		rr := testutil.NewRequest().Put(url).WithJsonBody(map[string]interface{}{"name": "qux"}).GoWithHTTPHandler(t, r).Recorder
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.JSONEq(t, fmt.Sprintf(`{"id":%d,"name":"qux"}`, int(ids[1])), rr.Body.String())
+	})
+
+	t.Run("SUCCESS_UpdatePet_Keeps_V2_Fields", func(t *testing.T) {
+		rr := testutil.NewRequest().Post("/v2/pets").WithJsonBody(map[string]interface{}{"name": "quux", "tags": []string{"t", "u"},
+			"species": "dog", "breed": "shiba", "birth_date": "2020-01-02", "sex": "male", "attributes": map[string]interface{}{"color": "red"}}).GoWithHTTPHandler(t, r).Recorder
+		assert.Equal(t, http.StatusCreated, rr.Code, rr.Body.String())
+		id := int(decodeObject(t, rr.Body.Bytes())["id"].(float64))
+
+		// v1 replaces only the fields it knows
+		rr = testutil.NewRequest().Put(fmt.Sprintf("%s/pets/%d", base, id)).WithJsonBody(map[string]interface{}{"name": "corge", "tag": "t"}).GoWithHTTPHandler(t, r).Recorder
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.JSONEq(t, fmt.Sprintf(`{"id":%d,"name":"corge","tag":"t"}`, id), rr.Body.String())
+
+		rr = doGet(t, r, fmt.Sprintf("/v2/pets/%d", id))
+		assert.JSONEq(t, fmt.Sprintf(`{"id":%d,"name":"corge","tag":"t","tags":["t","u"],"status":"available",
+			"species":"dog","breed":"shiba","birth_date":"2020-01-02","sex":"male","attributes":{"color":"red"}}`, id), rr.Body.String())
+
+		// fields added in v2 are replaced if sent, as PATCH does
+		rr = testutil.NewRequest().Put(fmt.Sprintf("%s/pets/%d", base, id)).WithJsonBody(map[string]interface{}{"name": "corge", "tag": "t",
+			"tags": []string{"t", "v"}, "breed": "akita"}).GoWithHTTPHandler(t, r).Recorder
+		assert.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+		assert.JSONEq(t, fmt.Sprintf(`{"id":%d,"name":"corge","tag":"t"}`, id), rr.Body.String())
+
+		rr = doGet(t, r, fmt.Sprintf("/v2/pets/%d", id))
+		assert.JSONEq(t, fmt.Sprintf(`{"id":%d,"name":"corge","tag":"t","tags":["t","v"],"status":"available",
+			"species":"dog","breed":"akita","birth_date":"2020-01-02","sex":"male","attributes":{"color":"red"}}`, id), rr.Body.String())
+	})
+
+	t.Run("SUCCESS_PatchPet", func(t *testing.T) {
+		url := fmt.Sprintf("%s/pets/%d", base, int(ids[0]))
+		rr := testutil.NewRequest().Patch(url).WithContentType("application/merge-patch+json").WithJsonBody(map[string]interface{}{"tag": nil}).GoWithHTTPHandler(t, r).Recorder
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.JSONEq(t, fmt.Sprintf(`{"id":%d,"name":"foo"}`, int(ids[0])), rr.Body.String())
+	})
+
+	t.Run("SUCCESS_DeletePet", func(t *testing.T) {
+		url := fmt.Sprintf("%s/pets/%d", base, int(ids[2]))
+		rr := testutil.NewRequest().Delete(url).GoWithHTTPHandler(t, r).Recorder
+		assert.Equal(t, http.StatusNoContent, rr.Code)
+		assert.Empty(t, rr.Body.String())
+	})
+
+	// abnormal 404
+	t.Run("ABNORMAL_FindPetById_NotFound", func(t *testing.T) {
+		rr := doGet(t, r, base+"/pets/10000")
+		assert.Equal(t, http.StatusNotFound, rr.Code)
+		assert.Equal(t, "@1792281600", rr.Header().Get("Deprecation"))
+
+		// error is the Error of the spec
+		e := decodeObject(t, rr.Body.Bytes())
+		assert.Equal(t, float64(http.StatusNotFound), e["code"])
+		assert.NotEmpty(t, e["message"])
+	})
+
+	t.Run("SUCCESS_AddPet_UnknownField", func(t *testing.T) {
+		rr := testutil.NewRequest().Post(base+"/pets").WithJsonBody(map[string]interface{}{"name": "foo", "color": "red"}).GoWithHTTPHandler(t, r).Recorder
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Empty(t, rr.Header().Get("Location"))
+	})
+
+	// abnormal 400
+	t.Run("ABNORMAL_AddPet_NoName", func(t *testing.T) {
+		rr := testutil.NewRequest().Post(base+"/pets").WithJsonBody(map[string]interface{}{"tag": "t"}).GoWithHTTPHandler(t, r).Recorder
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+		e := decodeObject(t, rr.Body.Bytes())
+		assert.Equal(t, float64(http.StatusBadRequest), e["code"])
+	})
+}
+
+func TestV2(t *testing.T) {
+	// database
+	db := setupDB(t)
+	defer db.Close()
+
+	// handlers
+	cfg := config.Default()
+	cfg.ResponseValidation = "fail"
+	r, err := newRouter(db, &cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for i, name := range []string{"foo", "bar", "baz"} {
+		rr := testutil.NewRequest().Post("/v2/pets").WithJsonBody(map[string]interface{}{"name": name}).GoWithHTTPHandler(t, r).Recorder
+		assert.Equal(t, http.StatusCreated, rr.Code)
+		assert.Equal(t, fmt.Sprintf("/v2/pets/%d", i+1), rr.Header().Get("Location"))
+		assert.Empty(t, rr.Header().Get("Deprecation"))
+	}
+
+	t.Run("SUCCESS_FindPets_PetList", func(t *testing.T) {
+		rr := doGet(t, r, "/v2/pets?limit=2")
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Empty(t, rr.Header().Get("Deprecation"))
+
+		list := struct {
+			Items      []map[string]interface{} `json:"items"`
+			NextCursor string                   `json:"next_cursor"`
+		}{}
+		err := json.Unmarshal(rr.Body.Bytes(), &list)
+		assert.NoError(t, err)
+		assert.Equal(t, 2, len(list.Items))
+		assert.Equal(t, rr.Header().Get("X-Next-Cursor"), list.NextCursor)
+
+		rr = doGet(t, r, "/v2/pets?limit=2&cursor="+list.NextCursor)
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.JSONEq(t, `{"items":[{"id":3,"name":"baz","status":"available"}]}`, rr.Body.String())
+	})
+
+	t.Run("SUCCESS_OpenAPIJSON", func(t *testing.T) {
+		rr := doGet(t, r, "/v2/openapi.json")
+		assert.Equal(t, http.StatusOK, rr.Code)
+		doc := decodeObject(t, rr.Body.Bytes())
+		assert.Equal(t, "2.0.0", doc["info"].(map[string]interface{})["version"])
+		assert.Equal(t, "http://example.com/v2", doc["servers"].([]interface{})[0].(map[string]interface{})["url"])
+	})
+
+	// abnormal 400
+	t.Run("ABNORMAL_UnknownField", func(t *testing.T) {
+		for _, req := range []*testutil.RequestBuilder{
+			testutil.NewRequest().Post("/v2/pets").WithJsonBody(map[string]interface{}{"name": "foo", "color": "red"}),
+			testutil.NewRequest().Put("/v2/pets/1").WithJsonBody(map[string]interface{}{"name": "foo", "color": "red"}),
+			testutil.NewRequest().Patch("/v2/pets/1").WithJsonBody(map[string]interface{}{"color": "red"}),
+		} {
+			rr := req.WithAccept("application/problem+json").GoWithHTTPHandler(t, r).Recorder
+			assert.Equal(t, http.StatusBadRequest, rr.Code)
+			assert.Contains(t, rr.Body.String(), `{"code":"unknown","field":"color"`)
+		}
+	})
+
+	// abnormal 404
+	t.Run("ABNORMAL_UnknownVersion", func(t *testing.T) {
+		rr := doGet(t, r, "/v3/pets")
+		assert.Equal(t, http.StatusNotFound, rr.Code)
+	})
+}
+
+func decodeObject(t *testing.T, b []byte) map[string]interface{} {
+	m := map[string]interface{}{}
+	assert.NoError(t, json.Unmarshal(b, &m), string(b))
+	return m
+}
+
+func keys(m map[string]interface{}) []string {
+	rslt := []string{}
+	for k := range m {
+		rslt = append(rslt, k)
+	}
+	sort.Strings(rslt)
+	return rslt
+}
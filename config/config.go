@@ -0,0 +1,277 @@
+// Package config loads application config in layers.
+//
+// Defaults are overridden by the yaml file, then by SCAPO_* environment variables.
+package config
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"os"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v2"
+)
+
+const (
+	// DefaultPath is read when no path is given, if exists.
+	DefaultPath = "config.yaml"
+	// EnvPrefix is the prefix of environment variables overriding the file.
+	EnvPrefix = "SCAPO_"
+)
+
+// Config of application.
+// yaml tag is the key in the file, env tag is the environment variable without EnvPrefix.
+type Config struct {
+	Listen          string `yaml:"Listen" env:"LISTEN"`
+	LogLevel        string `yaml:"LogLevel" env:"LOG_LEVEL"`
+	DefaultPageSize int    `yaml:"DefaultPageSize" env:"DEFAULT_PAGE_SIZE"`
+
+	// ReadinessTTL is how long a readiness check result is reused.
+	ReadinessTTL time.Duration `yaml:"ReadinessTTL" env:"READINESS_TTL"`
+	// ReadinessTimeout is the limit of a readiness check, independent of requests waiting for it.
+	ReadinessTimeout time.Duration `yaml:"ReadinessTimeout" env:"READINESS_TIMEOUT"`
+
+	DbDriver          string        `yaml:"DbDriver" env:"DB_DRIVER"`
+	DbDataSource      string        `yaml:"DbDataSource" env:"DB_DATA_SOURCE"`
+	DbMaxOpenConns    int           `yaml:"DbMaxOpenConns" env:"DB_MAX_OPEN_CONNS"`
+	DbMaxIdleConns    int           `yaml:"DbMaxIdleConns" env:"DB_MAX_IDLE_CONNS"`
+	DbConnMaxLifetime time.Duration `yaml:"DbConnMaxLifetime" env:"DB_CONN_MAX_LIFETIME"`
+	DbAutoMigrate     bool          `yaml:"DbAutoMigrate" env:"DB_AUTO_MIGRATE"`
+	DbConnectRetries  int           `yaml:"DbConnectRetries" env:"DB_CONNECT_RETRIES"`
+	DbConnectBackoff  time.Duration `yaml:"DbConnectBackoff" env:"DB_CONNECT_BACKOFF"`
+
+	RequestTimeout  time.Duration `yaml:"RequestTimeout" env:"REQUEST_TIMEOUT"`
+	ReadTimeout     time.Duration `yaml:"ReadTimeout" env:"READ_TIMEOUT"`
+	WriteTimeout    time.Duration `yaml:"WriteTimeout" env:"WRITE_TIMEOUT"`
+	IdleTimeout     time.Duration `yaml:"IdleTimeout" env:"IDLE_TIMEOUT"`
+	ShutdownTimeout time.Duration `yaml:"ShutdownTimeout" env:"SHUTDOWN_TIMEOUT"`
+
+	// MaxBodyBytes is the limit of request bodies, larger ones are 413.
+	MaxBodyBytes int `yaml:"MaxBodyBytes" env:"MAX_BODY_BYTES"`
+	// IdempotencyTTL is how long Idempotency-Key of POST /pets is replayed.
+	IdempotencyTTL time.Duration `yaml:"IdempotencyTTL" env:"IDEMPOTENCY_TTL"`
+
+	// TrashRetention is how long deleted pets can be restored before purged.
+	TrashRetention time.Duration `yaml:"TrashRetention" env:"TRASH_RETENTION"`
+	// PurgeInterval is the interval of the purge job, disabled if 0.
+	PurgeInterval time.Duration `yaml:"PurgeInterval" env:"PURGE_INTERVAL"`
+
+	// ResponseValidation checks responses against the spec, one of responseValidations.
+	ResponseValidation string `yaml:"ResponseValidation" env:"RESPONSE_VALIDATION"`
+	// ServeDocs serves /openapi.json, /openapi.yaml and /docs.
+	ServeDocs bool `yaml:"ServeDocs" env:"SERVE_DOCS"`
+	// TrustForwardedHeaders takes servers of the docs from X-Forwarded-Proto and X-Forwarded-Host,
+	// set it only behind a proxy overwriting them.
+	TrustForwardedHeaders bool `yaml:"TrustForwardedHeaders" env:"TRUST_FORWARDED_HEADERS"`
+
+	// V1Deprecation is the date v1 is deprecated since, like 2026-10-18.
+	V1Deprecation string `yaml:"V1Deprecation" env:"V1_DEPRECATION"`
+	// V1Sunset is the date v1 will be removed, not announced if empty.
+	V1Sunset string `yaml:"V1Sunset" env:"V1_SUNSET"`
+}
+
+// DateLayout is the layout of date fields.
+const DateLayout = "2006-01-02"
+
+// logLevels are allowed LogLevel.
+var logLevels = []string{"debug", "info", "warn", "error"}
+
+// responseValidations are allowed ResponseValidation.
+//   - off: not checked
+//   - log: mismatches are logged and responses are sent as is
+//   - fail: mismatches are replaced with 500
+var responseValidations = []string{"off", "log", "fail"}
+
+// Default returns Config used where neither the file nor the environment sets.
+func Default() Config {
+	return Config{
+		Listen:           "0.0.0.0:18080",
+		LogLevel:         "info",
+		DefaultPageSize:  100,
+		ReadinessTTL:     time.Second,
+		ReadinessTimeout: 2 * time.Second,
+
+		DbDriver:       "sqlite3",
+		DbDataSource:   "/tmp/scapo.db",
+		DbMaxOpenConns: 1,
+		DbMaxIdleConns: 1,
+		DbAutoMigrate:  true,
+
+		DbConnectRetries: 5,
+		DbConnectBackoff: time.Second,
+
+		RequestTimeout:  10 * time.Second,
+		ReadTimeout:     15 * time.Second,
+		WriteTimeout:    15 * time.Second,
+		IdleTimeout:     60 * time.Second,
+		ShutdownTimeout: 30 * time.Second,
+
+		MaxBodyBytes:   1 << 20,
+		IdempotencyTTL: 24 * time.Hour,
+
+		TrashRetention: 30 * 24 * time.Hour,
+		PurgeInterval:  time.Hour,
+
+		ResponseValidation: "off",
+		ServeDocs:          true,
+
+		V1Deprecation: "2026-10-18",
+	}
+}
+
+// Load returns validated Config.
+// The file at path must exist, DefaultPath is read if exists when path is empty.
+// lookupEnv is usually os.LookupEnv.
+func Load(path string, lookupEnv func(string) (string, bool)) (*Config, error) {
+	c := Default()
+
+	if path == "" {
+		if _, err := os.Stat(DefaultPath); err == nil {
+			path = DefaultPath
+		}
+	}
+	if path != "" {
+		buf, err := ioutil.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("config: %w", err)
+		}
+		if err := yaml.UnmarshalStrict(buf, &c); err != nil {
+			return nil, fmt.Errorf("config: %s: %w", path, err)
+		}
+	}
+
+	if err := c.overrideEnv(lookupEnv); err != nil {
+		return nil, err
+	}
+	if err := c.Validate(); err != nil {
+		return nil, err
+	}
+	return &c, nil
+}
+
+// Validate returns every invalid field in one error.
+func (c Config) Validate() error {
+	errs := []string{}
+	if _, _, err := net.SplitHostPort(c.Listen); err != nil {
+		errs = append(errs, fmt.Sprintf("Listen %q must be host:port", c.Listen))
+	}
+	if !contains(logLevels, c.LogLevel) {
+		errs = append(errs, fmt.Sprintf("LogLevel %q must be one of %s", c.LogLevel, strings.Join(logLevels, ", ")))
+	}
+	if !contains(responseValidations, c.ResponseValidation) {
+		errs = append(errs, fmt.Sprintf("ResponseValidation %q must be one of %s", c.ResponseValidation, strings.Join(responseValidations, ", ")))
+	}
+	if c.DefaultPageSize <= 0 {
+		errs = append(errs, fmt.Sprintf("DefaultPageSize %d must be positive", c.DefaultPageSize))
+	}
+	if !contains(sql.Drivers(), c.DbDriver) {
+		drivers := sql.Drivers()
+		sort.Strings(drivers)
+		errs = append(errs, fmt.Sprintf("DbDriver %q must be one of %s", c.DbDriver, strings.Join(drivers, ", ")))
+	}
+	if c.DbDataSource == "" {
+		errs = append(errs, "DbDataSource is required")
+	}
+	if c.MaxBodyBytes <= 0 {
+		errs = append(errs, fmt.Sprintf("MaxBodyBytes %d must be positive", c.MaxBodyBytes))
+	}
+	if c.ReadinessTimeout <= 0 {
+		errs = append(errs, fmt.Sprintf("ReadinessTimeout %s must be positive", c.ReadinessTimeout))
+	}
+	if c.IdempotencyTTL <= 0 {
+		errs = append(errs, fmt.Sprintf("IdempotencyTTL %s must be positive", c.IdempotencyTTL))
+	}
+	if c.TrashRetention <= 0 {
+		errs = append(errs, fmt.Sprintf("TrashRetention %s must be positive", c.TrashRetention))
+	}
+	if c.DbMaxOpenConns < 0 {
+		errs = append(errs, fmt.Sprintf("DbMaxOpenConns %d must not be negative", c.DbMaxOpenConns))
+	}
+	if c.DbConnectRetries < 0 {
+		errs = append(errs, fmt.Sprintf("DbConnectRetries %d must not be negative", c.DbConnectRetries))
+	}
+	if c.DbMaxIdleConns < 0 {
+		errs = append(errs, fmt.Sprintf("DbMaxIdleConns %d must not be negative", c.DbMaxIdleConns))
+	}
+	if _, err := time.Parse(DateLayout, c.V1Deprecation); err != nil {
+		errs = append(errs, fmt.Sprintf("V1Deprecation %q must be date like %s", c.V1Deprecation, DateLayout))
+	}
+	if _, err := time.Parse(DateLayout, c.V1Sunset); c.V1Sunset != "" && err != nil {
+		errs = append(errs, fmt.Sprintf("V1Sunset %q must be date like %s", c.V1Sunset, DateLayout))
+	}
+	for name, d := range map[string]time.Duration{
+		"ReadinessTTL":      c.ReadinessTTL,
+		"DbConnMaxLifetime": c.DbConnMaxLifetime,
+		"DbConnectBackoff":  c.DbConnectBackoff,
+		"RequestTimeout":    c.RequestTimeout,
+		"ReadTimeout":       c.ReadTimeout,
+		"WriteTimeout":      c.WriteTimeout,
+		"IdleTimeout":       c.IdleTimeout,
+		"ShutdownTimeout":   c.ShutdownTimeout,
+		"PurgeInterval":     c.PurgeInterval,
+	} {
+		if d < 0 {
+			errs = append(errs, fmt.Sprintf("%s %s must not be negative", name, d))
+		}
+	}
+
+	if len(errs) > 0 {
+		sort.Strings(errs)
+		return errors.New("config: " + strings.Join(errs, "; "))
+	}
+	return nil
+}
+
+// overrideEnv sets fields from EnvPrefix + env tag.
+func (c *Config) overrideEnv(lookupEnv func(string) (string, bool)) error {
+	v := reflect.ValueOf(c).Elem()
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		name := EnvPrefix + t.Field(i).Tag.Get("env")
+		s, ok := lookupEnv(name)
+		if !ok {
+			continue
+		}
+
+		f := v.Field(i)
+		switch f.Interface().(type) {
+		case string:
+			f.SetString(s)
+		case time.Duration:
+			d, err := time.ParseDuration(s)
+			if err != nil {
+				return fmt.Errorf("config: %s %q must be duration like 10s", name, s)
+			}
+			f.SetInt(int64(d))
+		case int:
+			n, err := strconv.Atoi(s)
+			if err != nil {
+				return fmt.Errorf("config: %s %q must be integer", name, s)
+			}
+			f.SetInt(int64(n))
+		case bool:
+			b, err := strconv.ParseBool(s)
+			if err != nil {
+				return fmt.Errorf("config: %s %q must be true or false", name, s)
+			}
+			f.SetBool(b)
+		}
+	}
+	return nil
+}
+
+func contains(ss []string, s string) bool {
+	for _, v := range ss {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,107 @@
+package config
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	// sqlite driver
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLoad(t *testing.T) {
+	dir, err := ioutil.TempDir("", "scapo-config")
+	if !assert.NoError(t, err) {
+		return
+	}
+	defer os.RemoveAll(dir)
+
+	writeFile := func(name string, body string) string {
+		path := filepath.Join(dir, name)
+		assert.NoError(t, ioutil.WriteFile(path, []byte(body), 0600))
+		return path
+	}
+	env := func(kv map[string]string) func(string) (string, bool) {
+		return func(key string) (string, bool) {
+			v, ok := kv[key]
+			return v, ok
+		}
+	}
+
+	t.Run("SUCCESS_Default", func(t *testing.T) {
+		assert.NoError(t, Default().Validate())
+	})
+
+	t.Run("SUCCESS_File", func(t *testing.T) {
+		path := writeFile("file.yaml", "DbDataSource: \":memory:\"\nRequestTimeout: \"3s\"\nDbMaxOpenConns: 4\n")
+		c, err := Load(path, env(nil))
+		assert.NoError(t, err)
+		assert.Equal(t, ":memory:", c.DbDataSource)
+		assert.Equal(t, 3*time.Second, c.RequestTimeout)
+		assert.Equal(t, 4, c.DbMaxOpenConns)
+		// default
+		assert.Equal(t, Default().Listen, c.Listen)
+	})
+
+	t.Run("SUCCESS_Env_Overrides_File", func(t *testing.T) {
+		path := writeFile("env.yaml", "DbDataSource: \":memory:\"\nLogLevel: \"debug\"\n")
+		c, err := Load(path, env(map[string]string{
+			"SCAPO_DB_DATA_SOURCE":    "/tmp/env.db",
+			"SCAPO_LISTEN":            "127.0.0.1:8080",
+			"SCAPO_DB_AUTO_MIGRATE":   "false",
+			"SCAPO_IDLE_TIMEOUT":      "1m",
+			"SCAPO_DEFAULT_PAGE_SIZE": "20",
+		}))
+		assert.NoError(t, err)
+		assert.Equal(t, "/tmp/env.db", c.DbDataSource)
+		assert.Equal(t, "127.0.0.1:8080", c.Listen)
+		assert.Equal(t, false, c.DbAutoMigrate)
+		assert.Equal(t, time.Minute, c.IdleTimeout)
+		assert.Equal(t, 20, c.DefaultPageSize)
+		assert.Equal(t, "debug", c.LogLevel)
+	})
+
+	// abnormal
+	t.Run("ABNORMAL_File_NotExist", func(t *testing.T) {
+		_, err := Load(filepath.Join(dir, "notexist.yaml"), env(nil))
+		assert.Error(t, err)
+	})
+
+	// abnormal
+	t.Run("ABNORMAL_File_UnknownKey", func(t *testing.T) {
+		path := writeFile("typo.yaml", "DbDataSorce: \":memory:\"\n")
+		_, err := Load(path, env(nil))
+		assert.Error(t, err)
+	})
+
+	// abnormal
+	t.Run("ABNORMAL_Env_Format", func(t *testing.T) {
+		_, err := Load("", env(map[string]string{"SCAPO_READ_TIMEOUT": "10"}))
+		assert.EqualError(t, err, `config: SCAPO_READ_TIMEOUT "10" must be duration like 10s`)
+	})
+
+	// abnormal
+	t.Run("ABNORMAL_Validate", func(t *testing.T) {
+		c := Default()
+		c.Listen = "18080"
+		c.LogLevel = "trace"
+		c.ResponseValidation = "panic"
+		c.V1Sunset = "next year"
+		c.DbDriver = "oracle"
+		c.DbDataSource = ""
+		c.DefaultPageSize = 0
+		c.ShutdownTimeout = -time.Second
+		c.IdempotencyTTL = 0
+		c.TrashRetention = 0
+		err := c.Validate()
+		if !assert.Error(t, err) {
+			return
+		}
+		for _, field := range []string{"Listen", "LogLevel", "DbDriver", "DbDataSource", "DefaultPageSize", "ShutdownTimeout", "ResponseValidation", "V1Sunset", "IdempotencyTTL", "TrashRetention"} {
+			assert.Contains(t, err.Error(), field)
+		}
+	})
+}
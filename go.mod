@@ -6,6 +6,7 @@ require (
 	github.com/asaskevich/govalidator v0.0.0-20210307081110-f21760c49a8d // indirect
 	github.com/deepmap/oapi-codegen v1.5.6
 	github.com/getkin/kin-openapi v0.47.0
+	github.com/ghodss/yaml v1.0.0
 	github.com/go-chi/chi/v5 v5.0.0
 	github.com/go-ozzo/ozzo-validation v3.6.0+incompatible
 	github.com/jmoiron/sqlx v1.3.1
@@ -0,0 +1,64 @@
+// Package logger writes leveled log through the standard logger.
+package logger
+
+import (
+	"log"
+	"strings"
+)
+
+// Level of log.
+type Level int
+
+const (
+	// LevelDebug for tracing.
+	LevelDebug Level = iota
+	// LevelInfo for lifecycle events.
+	LevelInfo
+	// LevelWarn for failures the client caused.
+	LevelWarn
+	// LevelError for failures the server caused.
+	LevelError
+)
+
+var level = LevelInfo
+
+// SetLevel sets minimum level written by name, debug, info, warn or error.
+func SetLevel(name string) {
+	switch strings.ToLower(name) {
+	case "debug":
+		level = LevelDebug
+	case "warn":
+		level = LevelWarn
+	case "error":
+		level = LevelError
+	default:
+		level = LevelInfo
+	}
+}
+
+// Debug writes log at LevelDebug.
+func Debug(v ...interface{}) {
+	output(LevelDebug, "DEBUG", v...)
+}
+
+// Info writes log at LevelInfo.
+func Info(v ...interface{}) {
+	output(LevelInfo, "INFO", v...)
+}
+
+// Warn writes log at LevelWarn.
+func Warn(v ...interface{}) {
+	output(LevelWarn, "WARN", v...)
+}
+
+// Error writes log at LevelError.
+func Error(v ...interface{}) {
+	output(LevelError, "ERROR", v...)
+}
+
+func output(l Level, prefix string, v ...interface{}) {
+	if l < level {
+		return
+	}
+	log.Println(append([]interface{}{prefix}, v...)...)
+}
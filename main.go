@@ -1,84 +1,286 @@
 package main
 
 import (
+	"context"
+	"encoding/json"
 	"flag"
 	"fmt"
-	"io/ioutil"
-	"log"
+	"io"
 	"net/http"
 	"os"
+	"os/signal"
+	"syscall"
+	"time"
 
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/getkin/kin-openapi/openapi3filter"
 	"github.com/go-chi/chi/v5"
 	"github.com/jmoiron/sqlx"
-	"gopkg.in/yaml.v2"
 
-	middleware "github.com/deepmap/oapi-codegen/pkg/chi-middleware"
+	"github.com/opbls/scapo/config"
+	"github.com/opbls/scapo/logger"
 	"github.com/opbls/scapo/petstore/delivery"
+	"github.com/opbls/scapo/petstore/migration"
 	"github.com/opbls/scapo/petstore/openapi"
+	"github.com/opbls/scapo/petstore/openapiv2"
 	"github.com/opbls/scapo/petstore/repository"
 	"github.com/opbls/scapo/petstore/usecase"
 )
 
 func init() {
-	dbConfig = databaseConfig{}
+	// PATCH accepts JSON Merge Patch
+	openapi3filter.RegisterBodyDecoder("application/merge-patch+json", mergePatchBodyDecoder)
+}
+
+func main() {
+	os.Exit(run())
+}
 
-	buf, err := ioutil.ReadFile("config.yaml")
+// run serves until SIGINT or SIGTERM and returns exit code.
+//   - 0: shutdown gracefully
+//   - 1: failed to start or serve
+//   - 2: in-flight requests not drained within ShutdownTimeout
+func run() int {
+
+	// config
+	configPath := flag.String("config", "", "Path to config file, "+config.DefaultPath+" if exists by default")
+	port := flag.Int("port", 0, "Port for HTTP server, overrides Listen")
+	flag.Parse()
+	cfg, err := config.Load(*configPath, os.LookupEnv)
 	if err != nil {
-		log.Fatalf("error: %v", err)
+		fmt.Fprintf(os.Stderr, "Error loading config\n: %s", err)
+		return 1
 	}
-	err = yaml.Unmarshal(buf, &dbConfig)
-	if err != nil {
-		log.Fatalf("error: %v", err)
+	if *port != 0 {
+		cfg.Listen = fmt.Sprintf("0.0.0.0:%d", *port)
 	}
-}
+	logger.SetLevel(cfg.LogLevel)
 
-func main() {
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
 
-	// address and port
-	port := flag.Int("port", 18080, "Port for test HTTP server")
-	flag.Parse()
-	addr := fmt.Sprintf("0.0.0.0:%d", *port)
+	// database
+	db, err := connectDB(ctx, cfg)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error connecting to database\n: %s", err)
+		return 1
+	}
+	defer func() {
+		if err := db.Close(); err != nil {
+			logger.Error("error closing database: ", err)
+		}
+	}()
 
-	// router swagger
-	router := chi.NewRouter()
-	swagger, err := openapi.GetSwagger()
+	// schema
+	migrator, err := migration.NewMigrator(db)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error loading migrations\n: %s", err)
+		return 1
+	}
+	if flag.Arg(0) == "migrate" {
+		err := runMigrate(ctx, migrator, flag.Args()[1:])
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error migrating database\n: %s", err)
+			return 1
+		}
+		return 0
+	}
+	if cfg.DbAutoMigrate {
+		if _, err := migrator.Up(ctx); err != nil {
+			fmt.Fprintf(os.Stderr, "Error migrating database\n: %s", err)
+			return 1
+		}
+	}
+	// search index, searches fall back on LIKE without it
+	if fts5, err := repository.SetupSearch(ctx, db); err != nil {
+		logger.Warn("error setting up search index: ", err)
+	} else if !fts5 {
+		logger.Info("sqlite is built without FTS5, search falls back on LIKE")
+	}
+
+	// router
+	router, err := newRouter(db, cfg)
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "Error loading swagger spec\n: %s", err)
-		os.Exit(1)
+		return 1
 	}
-	swagger.Servers = nil
-	router.Use(middleware.OapiRequestValidator(swagger))
 
-	// database
-	db, err := sqlx.Connect(dbConfig.getDbDriver(), dbConfig.getDbDataSource())
+	// trash
+	go runPurge(ctx, usecase.NewPetStoreUsecase(repository.NewUnitOfWork(db), repository.NewPetStoreRepository(db)), cfg.PurgeInterval, cfg.TrashRetention)
+
+	// server
+	server := &http.Server{
+		Addr:         cfg.Listen,
+		Handler:      router,
+		ReadTimeout:  cfg.ReadTimeout,
+		WriteTimeout: cfg.WriteTimeout,
+		IdleTimeout:  cfg.IdleTimeout,
+	}
+
+	return serve(ctx, server, cfg.ShutdownTimeout)
+}
+
+// newRouter returns handler of the API.
+// Health and docs endpoints are out of the spec, so they are mounted apart from the validators.
+func newRouter(db *sqlx.DB, cfg *config.Config) (chi.Router, error) {
+	router := chi.NewRouter()
+
+	migrator, err := migration.NewMigrator(db)
 	if err != nil {
-		fmt.Fprintf(os.Stderr, "Error connecting to database\n: %s", err)
+		return nil, err
 	}
-	defer db.Close()
 
 	// handlres
+	health := delivery.NewHealthDelivery(
+		usecase.NewHealthUsecase(repository.NewHealthRepository(db, migrator), cfg.ReadinessTTL, cfg.ReadinessTimeout))
 	repo := repository.NewPetStoreRepository(db)
-	usecase := usecase.NewPetStoreUsecase(repo)
-	handler := delivery.NewPetStoreDelivery(usecase)
+	idempotency := usecase.NewIdempotencyUsecase(repository.NewIdempotencyRepository(db), cfg.IdempotencyTTL, cfg.RequestTimeout)
+	owners := usecase.NewOwnerUsecase(repository.NewUnitOfWork(db), repository.NewOwnerRepository(db), repo)
+	usecase := usecase.NewPetStoreUsecase(repository.NewUnitOfWork(db), repo)
+	deliveryConfig := delivery.Config{
+		DefaultPageSize: cfg.DefaultPageSize,
+	}
+	v1 := delivery.NewPetStoreDelivery(usecase, idempotency, deliveryConfig)
+	v2 := delivery.NewPetStoreDeliveryV2(usecase, owners, idempotency, deliveryConfig)
 
-	// server
-	server := http.Server{}
-	server.Handler = openapi.HandlerFromMux(handler, router)
+	// validated by config
+	deprecation, _ := time.Parse(config.DateLayout, cfg.V1Deprecation)
+	sunset, _ := time.Parse(config.DateLayout, cfg.V1Sunset)
+	mountV1 := func(r chi.Router, basePath string) { openapi.HandlerFromMuxWithBaseURL(v1, r, basePath) }
+	mountV2 := func(r chi.Router, basePath string) { openapiv2.HandlerFromMuxWithBaseURL(v2, r, basePath) }
+	versions := []apiVersion{
+		// unversioned paths stay v1 for existing clients
+		{BasePath: "", GetSwagger: openapi.GetSwagger, Mount: mountV1, Deprecated: true},
+		{BasePath: "/v1", GetSwagger: openapi.GetSwagger, Mount: mountV1, Deprecated: true},
+		{BasePath: "/v2", GetSwagger: openapiv2.GetSwagger, Mount: mountV2},
+	}
+
+	router.Get("/healthz", health.Healthz)
+	router.Get("/readyz", health.Readyz)
+	for _, v := range versions {
+		if cfg.ServeDocs {
+			swagger, err := v.GetSwagger()
+			if err != nil {
+				return nil, err
+			}
+			// servers are rewritten to the base URL of each request
+			docs := delivery.NewDocsDelivery(swagger, v.BasePath, cfg.TrustForwardedHeaders)
+			router.Get(v.BasePath+"/openapi.json", docs.OpenAPIJSON)
+			router.Get(v.BasePath+"/openapi.yaml", docs.OpenAPIYAML)
+			router.Get(v.BasePath+"/docs", docs.Docs)
+		}
+
+		swagger, err := v.GetSwagger()
+		if err != nil {
+			return nil, err
+		}
+		// validators match paths under BasePath
+		swagger.Servers = nil
+		if v.BasePath != "" {
+			swagger.Servers = openapi3.Servers{{URL: v.BasePath}}
+		}
+
+		router.Group(func(r chi.Router) {
+			if v.Deprecated {
+				r.Use(delivery.Deprecated(deprecation, sunset))
+			}
+			r.Use(delivery.ResponseValidator(swagger, delivery.ResponseValidation(cfg.ResponseValidation)))
+			r.Use(delivery.LimitBody(int64(cfg.MaxBodyBytes)))
+			r.Use(delivery.RequestValidator(swagger))
+			r.Use(health.RequireReady)
+			// deadline of each request
+			r.Use(requestTimeout(cfg.RequestTimeout))
+			v.Mount(r, v.BasePath)
+		})
+	}
 
-	log.Fatal(http.ListenAndServe(addr, router))
+	return router, nil
 }
 
-type databaseConfig struct {
-	DbDriver     string `yaml:"DbDriver"`
-	DbDataSource string `yaml:"DbDataSource"`
+// apiVersion is a version of the API mounted at BasePath.
+type apiVersion struct {
+	BasePath   string
+	GetSwagger func() (*openapi3.Swagger, error)
+	Mount      func(r chi.Router, basePath string)
+	// Deprecated sets Deprecation and Sunset headers.
+	Deprecated bool
 }
 
-var dbConfig databaseConfig
+// connectDB connects database, retrying with exponential backoff while ctx is alive.
+func connectDB(ctx context.Context, cfg *config.Config) (*sqlx.DB, error) {
+	backoff := cfg.DbConnectBackoff
+	for attempt := 0; ; attempt++ {
+		db, err := sqlx.ConnectContext(ctx, cfg.DbDriver, cfg.DbDataSource)
+		if err == nil {
+			db.SetMaxOpenConns(cfg.DbMaxOpenConns)
+			db.SetMaxIdleConns(cfg.DbMaxIdleConns)
+			db.SetConnMaxLifetime(cfg.DbConnMaxLifetime)
+			return db, nil
+		}
+		if attempt >= cfg.DbConnectRetries {
+			return nil, err
+		}
 
-func (dbConfig databaseConfig) getDbDriver() string {
-	return dbConfig.DbDriver
+		logger.Warn(fmt.Sprintf("error connecting to database, retry in %s: ", backoff), err)
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(backoff):
+		}
+		backoff *= 2
+	}
+}
+
+// serve runs server until ctx is done, then drains in-flight requests within timeout.
+func serve(ctx context.Context, server *http.Server, timeout time.Duration) int {
+	errCh := make(chan error, 1)
+	go func() {
+		logger.Info("listening on ", server.Addr)
+		errCh <- server.ListenAndServe()
+	}()
+
+	select {
+	case err := <-errCh:
+		logger.Error("error serving: ", err)
+		return 1
+	case <-ctx.Done():
+	}
+
+	logger.Info("shutting down")
+	shutdownCtx := context.Background()
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		shutdownCtx, cancel = context.WithTimeout(shutdownCtx, timeout)
+		defer cancel()
+	}
+	if err := server.Shutdown(shutdownCtx); err != nil {
+		logger.Error("error shutting down: ", err)
+		server.Close()
+		return 2
+	}
+	logger.Info("shutdown completed")
+	return 0
+}
+
+// requestTimeout sets deadline to request context, no deadline if d is not positive.
+func requestTimeout(d time.Duration) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		if d <= 0 {
+			return next
+		}
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ctx, cancel := context.WithTimeout(r.Context(), d)
+			defer cancel()
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
 }
 
-func (dbConfig databaseConfig) getDbDataSource() string {
-	return dbConfig.DbDataSource
+// mergePatchBodyDecoder decode application/merge-patch+json body for request validator.
+func mergePatchBodyDecoder(body io.Reader, header http.Header, schema *openapi3.SchemaRef, encFn openapi3filter.EncodingFn) (interface{}, error) {
+	var value interface{}
+	if err := json.NewDecoder(body).Decode(&value); err != nil {
+		return nil, &openapi3filter.ParseError{Kind: openapi3filter.KindInvalidFormat, Cause: err}
+	}
+	return value, nil
 }
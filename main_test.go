@@ -1,19 +1,25 @@
 package main
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
+	"net"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
+	"time"
 
-	"github.com/deepmap/oapi-codegen/examples/petstore-expanded/chi/api"
-	middleware "github.com/deepmap/oapi-codegen/pkg/chi-middleware"
 	"github.com/deepmap/oapi-codegen/pkg/testutil"
+	"github.com/getkin/kin-openapi/openapi3"
 	"github.com/go-chi/chi/v5"
 	"github.com/jmoiron/sqlx"
+	"github.com/opbls/scapo/config"
 	"github.com/opbls/scapo/petstore/delivery"
+	"github.com/opbls/scapo/petstore/migration"
 	"github.com/opbls/scapo/petstore/openapi"
+	"github.com/opbls/scapo/petstore/openapiv2"
 	"github.com/opbls/scapo/petstore/repository"
 	"github.com/opbls/scapo/petstore/usecase"
 	"github.com/stretchr/testify/assert"
@@ -22,29 +28,17 @@ import (
 func TestHandler(t *testing.T) {
 	var err error
 
-	r := chi.NewRouter()
-	swagger, _ := openapi.GetSwagger()
-	swagger.Servers = nil
-	r.Use(middleware.OapiRequestValidator(swagger))
-
 	// database
-	ddl := `CREATE TABLE IF NOT EXISTS petstore(
-		id integer PRIMARY KEY autoincrement
-		, name text NOT NULL
-		, tag text
-	);`
-
-	db, _ := sqlx.Connect("sqlite3", ":memory:")
-	//db, _ := sqlx.Connect("sqlite3", "test.db")
+	db := setupDB(t)
 	defer db.Close()
 
-	db.MustExec(ddl)
-
 	// handlers
-	repo := repository.NewPetStoreRepository(db)
-	usecase := usecase.NewPetStoreUsecase(repo)
-	handler := delivery.NewPetStoreDelivery(usecase)
-	openapi.HandlerFromMux(handler, r)
+	cfg := config.Default()
+	cfg.ResponseValidation = "fail"
+	r, err := newRouter(db, &cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
 
 	//////////////////
 	// TEST DATA
@@ -61,8 +55,10 @@ func TestHandler(t *testing.T) {
 		newPetData = append(newPetData, popNewPet(name, tag))
 		petData = append(petData, popPet(i, name, tag))
 
-		dml := `insert into petstore(name, tag) values("` + name + `", "` + tag + `");`
+		dml := `insert into petstore(name) values("` + name + `");`
 		db.MustExec(dml)
+		db.MustExec(`insert into tags(name) values(?) on conflict(name) do nothing`, tag)
+		db.MustExec(`insert into pet_tags(pet_id, tag_id, position) select ?, id, 0 from tags where name = ?`, i, tag)
 	}
 	////////////////////
 	// TEST
@@ -172,6 +168,168 @@ func TestHandler(t *testing.T) {
 		assert.Equal(t, 0, len(rp))
 	})
 
+	t.Run("SUCCESS_FindPets_Cursor", func(t *testing.T) {
+		rp := []openapi.Pet{}
+
+		url := fmt.Sprintf("/pets?limit=%d", 3)
+		for pages := 0; url != ""; pages++ {
+			var page []openapi.Pet
+			rr := testutil.NewRequest().Get(url).WithAcceptJson().GoWithHTTPHandler(t, r).Recorder
+			assert.Equal(t, http.StatusOK, rr.Code)
+
+			err = json.NewDecoder(rr.Body).Decode(&page)
+			assert.NoError(t, err, "error unmarshal response")
+			rp = append(rp, page...)
+
+			url = ""
+			if link := rr.Header().Get("Link"); link != "" {
+				assert.NotEmpty(t, rr.Header().Get("X-Next-Cursor"))
+				url = strings.TrimSuffix(strings.TrimPrefix(link, "<"), `>; rel="next"`)
+			}
+			if !assert.Less(t, pages, len(petData)) {
+				break
+			}
+		}
+		assert.Equal(t, petData, rp)
+	})
+
+	t.Run("SUCCESS_FindPets_Cursor_LastPage", func(t *testing.T) {
+		url := fmt.Sprintf("/pets?limit=%d", len(petData))
+		rr := testutil.NewRequest().Get(url).WithAcceptJson().GoWithHTTPHandler(t, r).Recorder
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Empty(t, rr.Header().Get("Link"))
+	})
+
+	// abnormal 400
+	t.Run("ABNORMAL_FindPets_Cursor_Invalid", func(t *testing.T) {
+		url := fmt.Sprintf("/pets?cursor=%s", "!!")
+		rr := testutil.NewRequest().Get(url).WithAcceptJson().GoWithHTTPHandler(t, r).Recorder
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+	})
+
+	t.Run("SUCCESS_FindPets_Sort", func(t *testing.T) {
+		var rp []openapi.Pet
+
+		url := fmt.Sprintf("/pets?sort=%s", "-name")
+		rr := testutil.NewRequest().Get(url).WithAcceptJson().GoWithHTTPHandler(t, r).Recorder
+		assert.Equal(t, http.StatusOK, rr.Code)
+
+		err = json.NewDecoder(rr.Body).Decode(&rp)
+		assert.NoError(t, err, "error unmarshal response")
+		assert.Equal(t, len(petData), len(rp))
+		for i := 1; i < len(rp); i++ {
+			assert.GreaterOrEqual(t, rp[i-1].Name, rp[i].Name)
+		}
+	})
+
+	t.Run("SUCCESS_FindPets_Sort_Cursor", func(t *testing.T) {
+		var want []openapi.Pet
+		rr := doGet(t, r, "/pets?sort=-name")
+		err = json.NewDecoder(rr.Body).Decode(&want)
+		assert.NoError(t, err, "error unmarshal response")
+
+		rp := []openapi.Pet{}
+		url := fmt.Sprintf("/pets?sort=%s&limit=%d", "-name", 4)
+		for pages := 0; url != "" && pages < len(petData); pages++ {
+			var page []openapi.Pet
+			rr := testutil.NewRequest().Get(url).WithAcceptJson().GoWithHTTPHandler(t, r).Recorder
+			assert.Equal(t, http.StatusOK, rr.Code)
+
+			err = json.NewDecoder(rr.Body).Decode(&page)
+			assert.NoError(t, err, "error unmarshal response")
+			rp = append(rp, page...)
+
+			url = strings.TrimSuffix(strings.TrimPrefix(rr.Header().Get("Link"), "<"), `>; rel="next"`)
+		}
+		assert.Equal(t, want, rp)
+	})
+
+	t.Run("SUCCESS_FindPets_NamePrefix", func(t *testing.T) {
+		var rp []openapi.Pet
+
+		url := fmt.Sprintf("/pets?name_prefix=%s", testname+"1")
+		rr := testutil.NewRequest().Get(url).WithAcceptJson().GoWithHTTPHandler(t, r).Recorder
+		assert.Equal(t, http.StatusOK, rr.Code)
+
+		err = json.NewDecoder(rr.Body).Decode(&rp)
+		assert.NoError(t, err, "error unmarshal response")
+		// testname1, testname10
+		assert.Equal(t, 2, len(rp))
+	})
+
+	t.Run("SUCCESS_FindPets_NameContains", func(t *testing.T) {
+		var rp []openapi.Pet
+
+		url := fmt.Sprintf("/pets?name_contains=%s", "name1")
+		rr := testutil.NewRequest().Get(url).WithAcceptJson().GoWithHTTPHandler(t, r).Recorder
+		assert.Equal(t, http.StatusOK, rr.Code)
+
+		err = json.NewDecoder(rr.Body).Decode(&rp)
+		assert.NoError(t, err, "error unmarshal response")
+		assert.Equal(t, 2, len(rp))
+	})
+
+	// abnormal 200
+	t.Run("ABNORMAL_FindPets_NameContains_Wildcard", func(t *testing.T) {
+		var rp []openapi.Pet
+
+		url := fmt.Sprintf("/pets?name_contains=%s", "%25")
+		rr := testutil.NewRequest().Get(url).WithAcceptJson().GoWithHTTPHandler(t, r).Recorder
+		assert.Equal(t, http.StatusOK, rr.Code)
+
+		err = json.NewDecoder(rr.Body).Decode(&rp)
+		assert.NoError(t, err, "error unmarshal response")
+		assert.Equal(t, 0, len(rp))
+	})
+
+	t.Run("SUCCESS_FindPets_IdRange", func(t *testing.T) {
+		var rp []openapi.Pet
+
+		url := fmt.Sprintf("/pets?id_gt=%d&id_lt=%d", 2, 6)
+		rr := testutil.NewRequest().Get(url).WithAcceptJson().GoWithHTTPHandler(t, r).Recorder
+		assert.Equal(t, http.StatusOK, rr.Code)
+
+		err = json.NewDecoder(rr.Body).Decode(&rp)
+		assert.NoError(t, err, "error unmarshal response")
+		assert.Equal(t, petData[2:5], rp)
+	})
+
+	t.Run("SUCCESS_FindPets_HasTag", func(t *testing.T) {
+		var rp []openapi.Pet
+
+		url := fmt.Sprintf("/pets?has_tag=%t", false)
+		rr := testutil.NewRequest().Get(url).WithAcceptJson().GoWithHTTPHandler(t, r).Recorder
+		assert.Equal(t, http.StatusOK, rr.Code)
+
+		err = json.NewDecoder(rr.Body).Decode(&rp)
+		assert.NoError(t, err, "error unmarshal response")
+		assert.Equal(t, 0, len(rp))
+	})
+
+	// abnormal 400
+	t.Run("ABNORMAL_FindPets_Sort_Unknown", func(t *testing.T) {
+		url := fmt.Sprintf("/pets?sort=%s", "tag")
+		rr := testutil.NewRequest().Get(url).WithAcceptJson().GoWithHTTPHandler(t, r).Recorder
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+	})
+
+	// abnormal 400
+	t.Run("ABNORMAL_FindPets_Sort_Duplicated", func(t *testing.T) {
+		url := fmt.Sprintf("/pets?sort=%s", "name,-name")
+		rr := testutil.NewRequest().Get(url).WithAcceptJson().GoWithHTTPHandler(t, r).Recorder
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+	})
+
+	// abnormal 400
+	t.Run("ABNORMAL_FindPets_Cursor_OtherSort", func(t *testing.T) {
+		rr := doGet(t, r, "/pets?limit=1")
+		next := rr.Header().Get("X-Next-Cursor")
+
+		url := fmt.Sprintf("/pets?sort=%s&cursor=%s", "-name", next)
+		rr = testutil.NewRequest().Get(url).WithAcceptJson().GoWithHTTPHandler(t, r).Recorder
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+	})
+
 	//////////
 	//	FindPetById
 	//////////
@@ -188,7 +346,7 @@ func TestHandler(t *testing.T) {
 	})
 	// abnormal 400
 	t.Run("ABNORMAL_FindPetById_Nagative", func(t *testing.T) {
-		var rp openapi.Pet
+		var rp openapi.Problem
 
 		url := fmt.Sprintf("/pets/%d", -1)
 		rr := testutil.NewRequest().Get(url).WithAcceptJson().GoWithHTTPHandler(t, r).Recorder
@@ -199,14 +357,130 @@ func TestHandler(t *testing.T) {
 	})
 	// abnormal 404
 	t.Run("ABNORMAL_FindPetById_NotExist", func(t *testing.T) {
-		var rp openapi.Pet
+		var rp openapi.Problem
 
 		url := fmt.Sprintf("/pets/%d", 1000000)
 		rr := testutil.NewRequest().Get(url).WithAcceptJson().GoWithHTTPHandler(t, r).Recorder
 		assert.Equal(t, http.StatusNotFound, rr.Code)
+		assert.Equal(t, "application/json", rr.Header().Get("Content-Type"))
+
+		err = json.NewDecoder(rr.Body).Decode(&rp)
+		assert.NoError(t, err, "error unmarshal response")
+	})
+
+	//////////
+	//	UpdatePet
+	//////////
+	t.Run("SUCCESS_UpdatePet", func(t *testing.T) {
+		np := popNewPet("updatedname", "updatedtag")
+		p := popPet(2, np.Name, *np.Tag)
+		var rp openapi.Pet
+
+		url := fmt.Sprintf("/pets/%d", p.Id)
+		rr := testutil.NewRequest().Put(url).WithJsonBody(np).GoWithHTTPHandler(t, r).Recorder
+		assert.Equal(t, http.StatusOK, rr.Code)
+
+		err = json.NewDecoder(rr.Body).Decode(&rp)
+		assert.NoError(t, err, "error unmarshal response")
+		assert.Equal(t, p, rp)
+
+		rr = doGet(t, r, url)
+		err = json.NewDecoder(rr.Body).Decode(&rp)
+		assert.NoError(t, err, "error unmarshal response")
+		assert.Equal(t, p, rp)
+		petData[1] = p
+	})
+	// abnormal 400
+	t.Run("ABNORMAL_UpdatePet_NoName", func(t *testing.T) {
+
+		url := fmt.Sprintf("/pets/%d", 2)
+		rr := testutil.NewRequest().Put(url).WithJsonBody(map[string]string{"tag": "foo"}).GoWithHTTPHandler(t, r).Recorder
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+	})
+	// abnormal 404
+	t.Run("ABNORMAL_UpdatePet_NotFound", func(t *testing.T) {
+
+		url := fmt.Sprintf("/pets/%d", 10000)
+		rr := testutil.NewRequest().Put(url).WithJsonBody(popNewPet("foo", "bar")).GoWithHTTPHandler(t, r).Recorder
+		assert.Equal(t, http.StatusNotFound, rr.Code)
+	})
+
+	//////////
+	//	PatchPet
+	//////////
+	t.Run("SUCCESS_PatchPet_Name", func(t *testing.T) {
+		p := petData[1]
+		p.Name = "patchedname"
+		var rp openapi.Pet
+
+		url := fmt.Sprintf("/pets/%d", p.Id)
+		rr := testutil.NewRequest().Patch(url).WithContentType("application/merge-patch+json").WithBody([]byte(`{"name":"patchedname"}`)).GoWithHTTPHandler(t, r).Recorder
+		assert.Equal(t, http.StatusOK, rr.Code)
+
+		err = json.NewDecoder(rr.Body).Decode(&rp)
+		assert.NoError(t, err, "error unmarshal response")
+		assert.Equal(t, p, rp)
+		petData[1] = p
+	})
+
+	t.Run("SUCCESS_PatchPet_RemoveTag", func(t *testing.T) {
+		p := petData[1]
+		p.Tag = nil
+		var rp openapi.Pet
+
+		url := fmt.Sprintf("/pets/%d", p.Id)
+		rr := testutil.NewRequest().Patch(url).WithJsonBody(map[string]interface{}{"tag": nil}).GoWithHTTPHandler(t, r).Recorder
+		assert.Equal(t, http.StatusOK, rr.Code)
+
+		rr = doGet(t, r, url)
+		err = json.NewDecoder(rr.Body).Decode(&rp)
+		assert.NoError(t, err, "error unmarshal response")
+		assert.Equal(t, p, rp)
+
+		// restore
+		tag := testtag + "2"
+		rr = testutil.NewRequest().Patch(url).WithJsonBody(map[string]interface{}{"tag": tag}).GoWithHTTPHandler(t, r).Recorder
+		assert.Equal(t, http.StatusOK, rr.Code)
+		petData[1].Tag = &tag
+	})
+	// abnormal 400
+	t.Run("ABNORMAL_PatchPet_RemoveName", func(t *testing.T) {
+
+		url := fmt.Sprintf("/pets/%d", 2)
+		rr := testutil.NewRequest().Patch(url).WithJsonBody(map[string]interface{}{"name": nil}).GoWithHTTPHandler(t, r).Recorder
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+	})
+	// abnormal 400
+	t.Run("ABNORMAL_PatchPet_EmptyName", func(t *testing.T) {
+
+		url := fmt.Sprintf("/pets/%d", 2)
+		rr := testutil.NewRequest().Patch(url).WithJsonBody(map[string]interface{}{"name": ""}).GoWithHTTPHandler(t, r).Recorder
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+	})
+	// abnormal 400
+	t.Run("ABNORMAL_PatchPet_Problem", func(t *testing.T) {
+		var rp openapi.Problem
+
+		url := fmt.Sprintf("/pets/%d", 2)
+		rr := testutil.NewRequest().Patch(url).WithJsonBody(map[string]interface{}{"name": ""}).WithAccept("application/problem+json").GoWithHTTPHandler(t, r).Recorder
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+		assert.Equal(t, "application/problem+json", rr.Header().Get("Content-Type"))
 
 		err = json.NewDecoder(rr.Body).Decode(&rp)
 		assert.NoError(t, err, "error unmarshal response")
+		assert.Equal(t, int32(http.StatusBadRequest), rp.Code)
+		assert.Equal(t, "bad_request", *rp.ErrorCode)
+		assert.Equal(t, url, *rp.Instance)
+		if assert.NotNil(t, rp.Violations) && assert.Equal(t, 1, len(*rp.Violations)) {
+			assert.Equal(t, "name", (*rp.Violations)[0].Field)
+		}
+	})
+	// abnormal 404
+	t.Run("ABNORMAL_PatchPet_NotFound", func(t *testing.T) {
+
+		url := fmt.Sprintf("/pets/%d", 10000)
+		rr := testutil.NewRequest().Patch(url).WithJsonBody(map[string]interface{}{"name": "foo"}).GoWithHTTPHandler(t, r).Recorder
+		assert.Equal(t, http.StatusNotFound, rr.Code)
 	})
 
 	//////////
@@ -227,6 +501,26 @@ func TestHandler(t *testing.T) {
 		assert.Equal(t, np.Name, rp.Name)
 		assert.Equal(t, *rp.Tag, *rp.Tag)
 	})
+	// abnormal 400
+	t.Run("ABNORMAL_AddPet_Malformed", func(t *testing.T) {
+		var rp openapi.Problem
+
+		rr := testutil.NewRequest().Post("/pets").WithJsonContentType().WithBody([]byte(`{"name":`)).GoWithHTTPHandler(t, r).Recorder
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+
+		err = json.NewDecoder(rr.Body).Decode(&rp)
+		assert.NoError(t, err, "error unmarshal response")
+		if assert.NotNil(t, rp.Violations) {
+			assert.Equal(t, "body", (*rp.Violations)[0].Field)
+		}
+	})
+	// abnormal 413
+	t.Run("ABNORMAL_AddPet_TooLarge", func(t *testing.T) {
+		np := popNewPet(strings.Repeat("a", 1<<20), "bar")
+		rr := testutil.NewRequest().Post("/pets").WithJsonBody(np).GoWithHTTPHandler(t, r).Recorder
+		assert.Equal(t, http.StatusRequestEntityTooLarge, rr.Code)
+		assert.Contains(t, rr.Body.String(), "payload_too_large")
+	})
 	//////////
 	//	DeletePet
 	//////////
@@ -239,7 +533,7 @@ func TestHandler(t *testing.T) {
 		// slice update
 		petData = petData[:-1+len(petData)]
 
-		var rp []api.Pet
+		var rp []openapi.Pet
 		url = fmt.Sprintf("/pets")
 		rr = testutil.NewRequest().Get(url).WithAcceptJson().GoWithHTTPHandler(t, r).Recorder
 		assert.Equal(t, http.StatusOK, rr.Code)
@@ -267,7 +561,27 @@ func TestHandler(t *testing.T) {
 	})
 }
 
-func doGet(t *testing.T, mux *chi.Mux, url string) *httptest.ResponseRecorder {
+// setupDB returns in-memory database migrated to the latest schema.
+func setupDB(t *testing.T) *sqlx.DB {
+	db, err := sqlx.Connect("sqlite3", ":memory:")
+	//db, err := sqlx.Connect("sqlite3", "test.db")
+	if err != nil {
+		t.Fatal(err)
+	}
+	// every connection of :memory: is another database
+	db.SetMaxOpenConns(1)
+
+	migrator, err := migration.NewMigrator(db)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := migrator.Up(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	return db
+}
+
+func doGet(t *testing.T, mux http.Handler, url string) *httptest.ResponseRecorder {
 	response := testutil.NewRequest().Get(url).WithAcceptJson().GoWithHTTPHandler(t, mux)
 	return response.Recorder
 }
@@ -283,3 +597,1424 @@ func popPet(id int, name string, tag string) openapi.Pet {
 	ret.Tag = &tag
 	return ret
 }
+
+func TestRequestContext(t *testing.T) {
+	r := chi.NewRouter()
+	r.Use(requestTimeout(time.Nanosecond))
+
+	// database
+	db := setupDB(t)
+	defer db.Close()
+
+	// handlers
+	repo := repository.NewPetStoreRepository(db)
+	usecase := usecase.NewPetStoreUsecase(repository.NewUnitOfWork(db), repo)
+	handler := delivery.NewPetStoreDelivery(usecase, nil, delivery.Config{})
+	openapi.HandlerFromMux(handler, r)
+
+	// abnormal 504
+	t.Run("ABNORMAL_FindPets_DeadlineExceeded", func(t *testing.T) {
+		rr := doGet(t, r, "/pets")
+		assert.Equal(t, http.StatusGatewayTimeout, rr.Code)
+	})
+
+	// abnormal 499
+	t.Run("ABNORMAL_FindPetById_Canceled", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		req := httptest.NewRequest(http.MethodGet, "/pets/1", nil).WithContext(ctx)
+		rr := httptest.NewRecorder()
+		openapi.HandlerFromMux(handler, chi.NewRouter()).ServeHTTP(rr, req)
+		assert.Equal(t, 499, rr.Code)
+	})
+}
+
+func TestServe(t *testing.T) {
+	slow := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(200 * time.Millisecond)
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	// start server on a free port, send a slow request and then shutdown
+	shutdownWhileRequest := func(t *testing.T, timeout time.Duration) (int, int) {
+		l, err := net.Listen("tcp", "127.0.0.1:0")
+		if !assert.NoError(t, err) {
+			return -1, -1
+		}
+		addr := l.Addr().String()
+		l.Close()
+
+		ctx, cancel := context.WithCancel(context.Background())
+		server := &http.Server{Addr: addr, Handler: slow}
+		codeCh := make(chan int, 1)
+		go func() { codeCh <- serve(ctx, server, timeout) }()
+
+		statusCh := make(chan int, 1)
+		go func() {
+			for i := 0; i < 50; i++ {
+				res, err := http.Get("http://" + addr)
+				if err == nil {
+					res.Body.Close()
+					statusCh <- res.StatusCode
+					return
+				}
+				time.Sleep(10 * time.Millisecond)
+			}
+			statusCh <- -1
+		}()
+		time.Sleep(100 * time.Millisecond)
+		cancel()
+
+		return <-codeCh, <-statusCh
+	}
+
+	t.Run("SUCCESS_Shutdown_Drain", func(t *testing.T) {
+		code, status := shutdownWhileRequest(t, time.Second)
+		assert.Equal(t, 0, code)
+		assert.Equal(t, http.StatusNoContent, status)
+	})
+
+	// abnormal exit 2
+	t.Run("ABNORMAL_Shutdown_Timeout", func(t *testing.T) {
+		code, _ := shutdownWhileRequest(t, time.Millisecond)
+		assert.Equal(t, 2, code)
+	})
+
+	// abnormal exit 1
+	t.Run("ABNORMAL_Serve_AddrInUse", func(t *testing.T) {
+		l, err := net.Listen("tcp", "127.0.0.1:0")
+		if !assert.NoError(t, err) {
+			return
+		}
+		defer l.Close()
+
+		server := &http.Server{Addr: l.Addr().String(), Handler: slow}
+		assert.Equal(t, 1, serve(context.Background(), server, time.Second))
+	})
+}
+
+func TestHealth(t *testing.T) {
+	// database
+	db := setupDB(t)
+	defer db.Close()
+
+	// handlers
+	cfg := config.Default()
+	cfg.ReadinessTTL = 0
+	cfg.ResponseValidation = "fail"
+	r, err := newRouter(db, &cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("SUCCESS_Healthz", func(t *testing.T) {
+		rr := doGet(t, r, "/healthz")
+		assert.Equal(t, http.StatusOK, rr.Code)
+	})
+
+	t.Run("SUCCESS_Readyz", func(t *testing.T) {
+		rr := doGet(t, r, "/readyz")
+		assert.Equal(t, http.StatusOK, rr.Code)
+
+		rr = doGet(t, r, "/pets")
+		assert.Equal(t, http.StatusOK, rr.Code)
+	})
+
+	// abnormal 503
+	t.Run("ABNORMAL_Readyz_SchemaNotCurrent", func(t *testing.T) {
+		migrator, _ := migration.NewMigrator(db)
+		_, err := migrator.Down(context.Background())
+		assert.NoError(t, err)
+		defer migrator.Up(context.Background())
+
+		rr := doGet(t, r, "/readyz")
+		assert.Equal(t, http.StatusServiceUnavailable, rr.Code)
+
+		rr = doGet(t, r, "/pets")
+		assert.Equal(t, http.StatusServiceUnavailable, rr.Code)
+		assert.NotEmpty(t, rr.Header().Get("Retry-After"))
+	})
+
+	// abnormal 503
+	t.Run("ABNORMAL_Readyz_DBClosed", func(t *testing.T) {
+		db.Close()
+
+		rr := doGet(t, r, "/healthz")
+		assert.Equal(t, http.StatusOK, rr.Code)
+
+		rr = doGet(t, r, "/readyz")
+		assert.Equal(t, http.StatusServiceUnavailable, rr.Code)
+
+		rr = doGet(t, r, "/pets/1")
+		assert.Equal(t, http.StatusServiceUnavailable, rr.Code)
+	})
+}
+
+func TestReadyzCache(t *testing.T) {
+	// database
+	db := setupDB(t)
+	defer db.Close()
+
+	// handlers
+	cfg := config.Default()
+	cfg.ReadinessTTL = time.Minute
+	r, err := newRouter(db, &cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("SUCCESS_Readyz_Cancelled_Not_Cached", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		rr := httptest.NewRecorder()
+		r.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/readyz", nil).WithContext(ctx))
+
+		rr = doGet(t, r, "/readyz")
+		assert.Equal(t, http.StatusOK, rr.Code)
+	})
+
+	t.Run("SUCCESS_Readyz_Cached", func(t *testing.T) {
+		db.Close()
+		rr := doGet(t, r, "/readyz")
+		assert.Equal(t, http.StatusOK, rr.Code)
+	})
+}
+
+func TestDocs(t *testing.T) {
+	// database
+	db := setupDB(t)
+	defer db.Close()
+
+	// handlers
+	cfg := config.Default()
+	cfg.ResponseValidation = "fail"
+	r, err := newRouter(db, &cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("SUCCESS_OpenAPIJSON", func(t *testing.T) {
+		rr := doGet(t, r, "/openapi.json")
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Equal(t, "application/json", rr.Header().Get("Content-Type"))
+
+		swagger, err := openapi3.NewSwaggerLoader().LoadSwaggerFromData(rr.Body.Bytes())
+		if !assert.NoError(t, err) {
+			return
+		}
+		assert.Equal(t, "http://example.com", swagger.Servers[0].URL)
+		assert.NotNil(t, swagger.Paths.Find("/pets/{id}"))
+	})
+
+	t.Run("SUCCESS_OpenAPIYAML_Forwarded", func(t *testing.T) {
+		forwarded := func(r http.Handler) *httptest.ResponseRecorder {
+			return testutil.NewRequest().Get("/openapi.yaml").
+				WithHeader("X-Forwarded-Proto", "https").
+				WithHeader("X-Forwarded-Host", "api.example.com, proxy.local").
+				GoWithHTTPHandler(t, r).Recorder
+		}
+		// not trusted by default
+		rr := forwarded(r)
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Equal(t, "application/yaml", rr.Header().Get("Content-Type"))
+		swagger, err := openapi3.NewSwaggerLoader().LoadSwaggerFromData(rr.Body.Bytes())
+		if !assert.NoError(t, err) {
+			return
+		}
+		assert.Equal(t, "http://example.com", swagger.Servers[0].URL)
+
+		trusted := cfg
+		trusted.TrustForwardedHeaders = true
+		tr, err := newRouter(db, &trusted)
+		if err != nil {
+			t.Fatal(err)
+		}
+		rr = forwarded(tr)
+		swagger, err = openapi3.NewSwaggerLoader().LoadSwaggerFromData(rr.Body.Bytes())
+		if !assert.NoError(t, err) {
+			return
+		}
+		assert.Equal(t, "https://api.example.com", swagger.Servers[0].URL)
+	})
+
+	t.Run("SUCCESS_Docs", func(t *testing.T) {
+		rr := testutil.NewRequest().Get("/docs").GoWithHTTPHandler(t, r).Recorder
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Contains(t, rr.Header().Get("Content-Type"), "text/html")
+		assert.Contains(t, rr.Body.String(), "openapi.json")
+		// offline
+		assert.NotContains(t, rr.Body.String(), "<script src=")
+	})
+
+	// abnormal 404
+	t.Run("ABNORMAL_Docs_Disabled", func(t *testing.T) {
+		cfg := config.Default()
+		cfg.ServeDocs = false
+		r, err := newRouter(db, &cfg)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, url := range []string{"/openapi.json", "/openapi.yaml", "/docs"} {
+			rr := doGet(t, r, url)
+			assert.Equal(t, http.StatusNotFound, rr.Code, url)
+		}
+	})
+}
+
+func TestResponseValidator(t *testing.T) {
+	swagger, err := openapi.GetSwagger()
+	if err != nil {
+		t.Fatal(err)
+	}
+	swagger.Servers = nil
+
+	// handler drifting from the spec
+	drift := func(status int, contentType string, body string) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", contentType)
+			w.WriteHeader(status)
+			w.Write([]byte(body))
+		})
+	}
+	pet := `{"id":1,"name":"cat"}`
+
+	t.Run("SUCCESS_ResponseValidator_Match", func(t *testing.T) {
+		h := delivery.ResponseValidator(swagger, delivery.ResponseValidationFail)(drift(http.StatusOK, "application/json", pet))
+		rr := doGet(t, h, "/pets/1")
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.JSONEq(t, pet, rr.Body.String())
+	})
+
+	t.Run("SUCCESS_ResponseValidator_Log", func(t *testing.T) {
+		h := delivery.ResponseValidator(swagger, delivery.ResponseValidationLog)(drift(http.StatusCreated, "application/json", pet))
+		rr := doGet(t, h, "/pets/1")
+		assert.Equal(t, http.StatusCreated, rr.Code)
+	})
+
+	// abnormal 500
+	t.Run("ABNORMAL_ResponseValidator_Fail", func(t *testing.T) {
+		for name, h := range map[string]http.Handler{
+			"status":       drift(http.StatusCreated, "application/json", pet),
+			"content type": drift(http.StatusOK, "text/plain", pet),
+			"body":         drift(http.StatusOK, "application/json", `{"id":"1"}`),
+			"error body":   drift(http.StatusBadRequest, "text/plain; charset=utf-8", "Invalid format for parameter id"),
+		} {
+			rr := doGet(t, delivery.ResponseValidator(swagger, delivery.ResponseValidationFail)(h), "/pets/1")
+			assert.Equal(t, http.StatusInternalServerError, rr.Code, name)
+		}
+	})
+}
+
+func TestConnectDB(t *testing.T) {
+	// abnormal
+	t.Run("ABNORMAL_ConnectDB_Retries", func(t *testing.T) {
+		cfg := config.Default()
+		cfg.DbDataSource = "/nonexistent/dir/scapo.db"
+		cfg.DbConnectRetries = 2
+		cfg.DbConnectBackoff = 10 * time.Millisecond
+
+		start := time.Now()
+		_, err := connectDB(context.Background(), &cfg)
+		assert.Error(t, err)
+		// 10ms + 20ms
+		assert.GreaterOrEqual(t, int64(time.Since(start)), int64(30*time.Millisecond))
+	})
+
+	// abnormal
+	t.Run("ABNORMAL_ConnectDB_Canceled", func(t *testing.T) {
+		cfg := config.Default()
+		cfg.DbDataSource = "/nonexistent/dir/scapo.db"
+		cfg.DbConnectBackoff = time.Hour
+
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+		_, err := connectDB(ctx, &cfg)
+		assert.Error(t, err)
+	})
+}
+
+func TestIdempotency(t *testing.T) {
+	// database
+	db := setupDB(t)
+	defer db.Close()
+
+	// handlers
+	cfg := config.Default()
+	cfg.ResponseValidation = "fail"
+	r, err := newRouter(db, &cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	addPet := func(url string, key string, body interface{}) *httptest.ResponseRecorder {
+		return testutil.NewRequest().Post(url).WithHeader("Idempotency-Key", key).WithJsonBody(body).GoWithHTTPHandler(t, r).Recorder
+	}
+	countPets := func(url string) int {
+		pets := []openapi.Pet{}
+		json.NewDecoder(doGet(t, r, url).Body).Decode(&pets)
+		return len(pets)
+	}
+
+	t.Run("SUCCESS_AddPet_Replayed", func(t *testing.T) {
+		first := addPet("/pets", "replay", popNewPet("foo", "bar"))
+		assert.Equal(t, http.StatusOK, first.Code)
+		assert.Empty(t, first.Header().Get("Idempotent-Replayed"))
+
+		// fields in another order are the same request
+		second := addPet("/pets", "replay", map[string]interface{}{"tag": "bar", "name": "foo"})
+		assert.Equal(t, http.StatusOK, second.Code)
+		assert.Equal(t, "true", second.Header().Get("Idempotent-Replayed"))
+		assert.Equal(t, "application/json", second.Header().Get("Content-Type"))
+		assert.JSONEq(t, first.Body.String(), second.Body.String())
+		assert.Equal(t, 1, countPets("/pets?name_prefix=foo"))
+	})
+
+	t.Run("SUCCESS_AddPet_ReplayedV2", func(t *testing.T) {
+		first := addPet("/v2/pets", "replay", popNewPet("v2", ""))
+		assert.Equal(t, http.StatusCreated, first.Code)
+
+		// keys are scoped by path
+		second := addPet("/v2/pets", "replay", popNewPet("v2", ""))
+		assert.Equal(t, http.StatusCreated, second.Code)
+		assert.Equal(t, first.Header().Get("Location"), second.Header().Get("Location"))
+		assert.Equal(t, "true", second.Header().Get("Idempotent-Replayed"))
+		assert.JSONEq(t, first.Body.String(), second.Body.String())
+	})
+
+	t.Run("SUCCESS_AddPet_ReplayedError", func(t *testing.T) {
+		// invalid pet, not rejected by the request validator
+		first := addPet("/pets", "error", popNewPet("", "bar"))
+		assert.Equal(t, http.StatusBadRequest, first.Code)
+
+		second := addPet("/pets", "error", popNewPet("", "bar"))
+		assert.Equal(t, http.StatusBadRequest, second.Code)
+		assert.Equal(t, "true", second.Header().Get("Idempotent-Replayed"))
+	})
+
+	t.Run("SUCCESS_AddPet_Concurrent", func(t *testing.T) {
+		codes := make(chan int, 10)
+		for i := 0; i < cap(codes); i++ {
+			go func() {
+				codes <- addPet("/pets", "concurrent", popNewPet("concurrent", "")).Code
+			}()
+		}
+		for i := 0; i < cap(codes); i++ {
+			// in progress or replayed
+			assert.Contains(t, []int{http.StatusOK, http.StatusConflict}, <-codes)
+		}
+		assert.Equal(t, 1, countPets("/pets?name_prefix=concurrent"))
+	})
+
+	t.Run("SUCCESS_AddPet_LeaseExpired", func(t *testing.T) {
+		// the request in progress failed to complete, its lease is RequestTimeout
+		db.MustExec(`INSERT INTO idempotency_keys(scope, key, request_hash, created_at) VALUES(?, ?, ?, ?)`,
+			"POST /pets", "lease", "x", time.Now().Add(-cfg.RequestTimeout-time.Second).UTC())
+		rr := addPet("/pets", "lease", popNewPet("lease", ""))
+		assert.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+		assert.Empty(t, rr.Header().Get("Idempotent-Replayed"))
+	})
+
+	// abnormal 422
+	t.Run("ABNORMAL_AddPet_KeyReused", func(t *testing.T) {
+		rr := addPet("/pets", "reused", popNewPet("foo", ""))
+		assert.Equal(t, http.StatusOK, rr.Code)
+
+		rr = addPet("/pets", "reused", popNewPet("bar", ""))
+		assert.Equal(t, http.StatusUnprocessableEntity, rr.Code)
+		assert.Contains(t, rr.Body.String(), `"field":"Idempotency-Key"`)
+		assert.Equal(t, 0, countPets("/pets?name_prefix=bar"))
+	})
+
+	// abnormal 400
+	t.Run("ABNORMAL_AddPet_KeyTooLong", func(t *testing.T) {
+		rr := addPet("/pets", strings.Repeat("k", 256), popNewPet("foo", ""))
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+	})
+}
+
+func TestConditional(t *testing.T) {
+	// database
+	db := setupDB(t)
+	defer db.Close()
+
+	// handlers
+	cfg := config.Default()
+	cfg.ResponseValidation = "fail"
+	r, err := newRouter(db, &cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, name := range []string{"foo", "bar"} {
+		testutil.NewRequest().Post("/pets").WithJsonBody(popNewPet(name, "")).GoWithHTTPHandler(t, r)
+	}
+	withHeader := func(req *testutil.RequestBuilder, key string, value string) *httptest.ResponseRecorder {
+		return req.WithHeader(key, value).WithAcceptJson().GoWithHTTPHandler(t, r).Recorder
+	}
+
+	t.Run("SUCCESS_FindPetById_NotModified", func(t *testing.T) {
+		rr := doGet(t, r, "/pets/1")
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Equal(t, `"1"`, rr.Header().Get("ETag"))
+
+		rr = withHeader(testutil.NewRequest().Get("/pets/1"), "If-None-Match", `"0", W/"1"`)
+		assert.Equal(t, http.StatusNotModified, rr.Code)
+		assert.Equal(t, `"1"`, rr.Header().Get("ETag"))
+		assert.Empty(t, rr.Body.String())
+
+		rr = withHeader(testutil.NewRequest().Get("/v2/pets/1"), "If-None-Match", `"0"`)
+		assert.Equal(t, http.StatusOK, rr.Code)
+	})
+
+	t.Run("SUCCESS_FindPets_NotModified", func(t *testing.T) {
+		for _, url := range []string{"/pets", "/v2/pets"} {
+			rr := doGet(t, r, url)
+			assert.Equal(t, http.StatusOK, rr.Code)
+			etag := rr.Header().Get("ETag")
+			assert.NotEmpty(t, etag)
+
+			rr = withHeader(testutil.NewRequest().Get(url), "If-None-Match", etag)
+			assert.Equal(t, http.StatusNotModified, rr.Code, url)
+		}
+		v1 := doGet(t, r, "/pets").Header().Get("ETag")
+		assert.NotEqual(t, v1, doGet(t, r, "/pets?limit=1").Header().Get("ETag"))
+		assert.NotEqual(t, v1, doGet(t, r, "/v2/pets").Header().Get("ETag"))
+	})
+
+	t.Run("SUCCESS_UpdatePet_IfMatch", func(t *testing.T) {
+		rr := withHeader(testutil.NewRequest().Put("/pets/1").WithJsonBody(popNewPet("foo", "t")), "If-Match", `"1"`)
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Equal(t, `"2"`, rr.Header().Get("ETag"))
+
+		rr = withHeader(testutil.NewRequest().Patch("/pets/1").WithJsonBody(map[string]interface{}{"tag": nil}), "If-Match", `"2"`)
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Equal(t, `"3"`, rr.Header().Get("ETag"))
+
+		// any version
+		rr = withHeader(testutil.NewRequest().Put("/v2/pets/1").WithJsonBody(popNewPet("foo", "")), "If-Match", "*")
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Equal(t, `"4"`, rr.Header().Get("ETag"))
+
+		// modified, not changed on 304 either
+		rr = withHeader(testutil.NewRequest().Get("/pets/1"), "If-None-Match", `"1"`)
+		assert.Equal(t, http.StatusOK, rr.Code)
+	})
+
+	t.Run("SUCCESS_DeletePet_IfMatch", func(t *testing.T) {
+		rr := withHeader(testutil.NewRequest().Delete("/pets/2"), "If-Match", `"1"`)
+		assert.Equal(t, http.StatusNoContent, rr.Code)
+	})
+
+	// abnormal 412
+	t.Run("ABNORMAL_PreconditionFailed", func(t *testing.T) {
+		for _, req := range []*testutil.RequestBuilder{
+			testutil.NewRequest().Put("/pets/1").WithJsonBody(popNewPet("lost", "")),
+			testutil.NewRequest().Patch("/pets/1").WithJsonBody(map[string]interface{}{"name": "lost"}),
+			testutil.NewRequest().Patch("/v2/pets/1").WithJsonBody(map[string]interface{}{"name": "lost"}),
+			testutil.NewRequest().Delete("/pets/1"),
+		} {
+			for _, etag := range []string{`"1"`, `W/"4"`, `"foo"`} {
+				rr := withHeader(req, "If-Match", etag)
+				assert.Equal(t, http.StatusPreconditionFailed, rr.Code, etag)
+				assert.Contains(t, rr.Body.String(), "precondition_failed")
+			}
+		}
+
+		rr := doGet(t, r, "/pets/1")
+		assert.JSONEq(t, `{"id":1,"name":"foo"}`, rr.Body.String())
+		assert.Equal(t, `"4"`, rr.Header().Get("ETag"))
+	})
+
+	// abnormal 404
+	t.Run("ABNORMAL_IfMatch_NotFound", func(t *testing.T) {
+		rr := withHeader(testutil.NewRequest().Delete("/pets/2"), "If-Match", `"1"`)
+		assert.Equal(t, http.StatusNotFound, rr.Code)
+	})
+
+	// abnormal 400
+	t.Run("ABNORMAL_IfMatch_Multiple", func(t *testing.T) {
+		rr := withHeader(testutil.NewRequest().Delete("/pets/1"), "If-Match", `"3", "4"`)
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+	})
+}
+
+func TestTrash(t *testing.T) {
+	// database
+	db := setupDB(t)
+	defer db.Close()
+
+	// handlers
+	cfg := config.Default()
+	cfg.ResponseValidation = "fail"
+	r, err := newRouter(db, &cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, name := range []string{"foo", "bar", "baz"} {
+		testutil.NewRequest().Post("/pets").WithJsonBody(popNewPet(name, "")).GoWithHTTPHandler(t, r)
+	}
+	for _, id := range []int{2, 3} {
+		rr := testutil.NewRequest().Delete(fmt.Sprintf("/pets/%d", id)).GoWithHTTPHandler(t, r).Recorder
+		assert.Equal(t, http.StatusNoContent, rr.Code)
+	}
+	ids := func(url string) []int64 {
+		rslts := []int64{}
+		pets := []openapi.Pet{}
+		json.NewDecoder(doGet(t, r, url).Body).Decode(&pets)
+		for _, p := range pets {
+			rslts = append(rslts, p.Id)
+		}
+		return rslts
+	}
+
+	t.Run("SUCCESS_FindPets_ExcludeDeleted", func(t *testing.T) {
+		assert.Equal(t, []int64{1}, ids("/pets"))
+		assert.Equal(t, []int64{1}, ids("/pets?include_deleted=false"))
+	})
+
+	t.Run("SUCCESS_FindPets_IncludeDeleted", func(t *testing.T) {
+		rr := doGet(t, r, "/pets?include_deleted=true")
+		assert.Equal(t, http.StatusOK, rr.Code)
+		pets := []map[string]interface{}{}
+		json.Unmarshal(rr.Body.Bytes(), &pets)
+		if assert.Equal(t, 3, len(pets)) {
+			assert.Nil(t, pets[0]["deleted_at"])
+			assert.NotEmpty(t, pets[1]["deleted_at"])
+		}
+
+		rr = doGet(t, r, "/v2/pets?include_deleted=true&limit=1&cursor="+doGet(t, r, "/v2/pets?include_deleted=true&limit=1").Header().Get("X-Next-Cursor"))
+		assert.Contains(t, rr.Body.String(), `"deleted_at"`)
+	})
+
+	t.Run("SUCCESS_RestorePet", func(t *testing.T) {
+		rr := testutil.NewRequest().Post("/v2/pets/2:restore").GoWithHTTPHandler(t, r).Recorder
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.JSONEq(t, `{"id":2,"name":"bar","status":"available"}`, rr.Body.String())
+		assert.Equal(t, `"3"`, rr.Header().Get("ETag"))
+
+		assert.Equal(t, http.StatusOK, doGet(t, r, "/pets/2").Code)
+		assert.Equal(t, []int64{1, 2}, ids("/pets"))
+	})
+
+	t.Run("SUCCESS_PurgePets", func(t *testing.T) {
+		// not yet expired
+		purgePets(context.Background(), usecase.NewPetStoreUsecase(repository.NewUnitOfWork(db), repository.NewPetStoreRepository(db)), time.Hour)
+		assert.Equal(t, []int64{1, 2, 3}, ids("/pets?include_deleted=true"))
+
+		purgePets(context.Background(), usecase.NewPetStoreUsecase(repository.NewUnitOfWork(db), repository.NewPetStoreRepository(db)), -time.Second)
+		assert.Equal(t, []int64{1, 2}, ids("/pets?include_deleted=true"))
+	})
+
+	// abnormal 404
+	t.Run("ABNORMAL_Deleted_NotFound", func(t *testing.T) {
+		testutil.NewRequest().Delete("/pets/1").GoWithHTTPHandler(t, r)
+		for _, req := range []*testutil.RequestBuilder{
+			testutil.NewRequest().Get("/pets/1"),
+			testutil.NewRequest().Delete("/pets/1"),
+			testutil.NewRequest().Put("/pets/1").WithJsonBody(popNewPet("foo", "")),
+			testutil.NewRequest().Patch("/pets/1").WithJsonBody(map[string]interface{}{"name": "foo"}),
+			// not deleted
+			testutil.NewRequest().Post("/pets/2:restore"),
+			// purged
+			testutil.NewRequest().Post("/pets/3:restore"),
+		} {
+			rr := req.GoWithHTTPHandler(t, r).Recorder
+			assert.Equal(t, http.StatusNotFound, rr.Code, rr.Body.String())
+		}
+	})
+}
+
+func TestBatch(t *testing.T) {
+	// database
+	db := setupDB(t)
+	defer db.Close()
+
+	// handlers
+	cfg := config.Default()
+	cfg.ResponseValidation = "fail"
+	r, err := newRouter(db, &cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	type result struct {
+		Committed bool `json:"committed"`
+		Results   []struct {
+			Index  int                    `json:"index"`
+			Status int                    `json:"status"`
+			ID     int64                  `json:"id"`
+			Pet    *openapi.Pet           `json:"pet"`
+			Error  map[string]interface{} `json:"error"`
+		} `json:"results"`
+	}
+	do := func(req *testutil.RequestBuilder) result {
+		rr := req.GoWithHTTPHandler(t, r).Recorder
+		assert.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+		rslt := result{}
+		assert.NoError(t, json.Unmarshal(rr.Body.Bytes(), &rslt))
+		return rslt
+	}
+	statuses := func(rslt result) []int {
+		rslts := []int{}
+		for _, item := range rslt.Results {
+			rslts = append(rslts, item.Status)
+		}
+		return rslts
+	}
+	count := func() int {
+		pets := []openapi.Pet{}
+		json.NewDecoder(doGet(t, r, "/pets").Body).Decode(&pets)
+		return len(pets)
+	}
+
+	t.Run("SUCCESS_AddPets_Atomic", func(t *testing.T) {
+		rslt := do(testutil.NewRequest().Post("/pets:batch").WithJsonBody([]openapi.NewPet{popNewPet("foo", "t"), popNewPet("bar", "")}))
+		assert.True(t, rslt.Committed)
+		assert.Equal(t, []int{http.StatusCreated, http.StatusCreated}, statuses(rslt))
+		if assert.NotNil(t, rslt.Results[1].Pet) {
+			assert.Equal(t, "bar", rslt.Results[1].Pet.Name)
+			assert.Equal(t, rslt.Results[1].ID, rslt.Results[1].Pet.Id)
+		}
+		assert.Equal(t, 2, count())
+	})
+
+	t.Run("SUCCESS_AddPets_BestEffort", func(t *testing.T) {
+		rslt := do(testutil.NewRequest().Post("/v2/pets:batch?mode=best_effort").WithJsonBody([]openapi.NewPet{popNewPet("baz", ""), popNewPet("", "")}))
+		assert.True(t, rslt.Committed)
+		assert.Equal(t, []int{http.StatusCreated, http.StatusBadRequest}, statuses(rslt))
+		assert.Equal(t, "bad_request", rslt.Results[1].Error["error_code"])
+		assert.Equal(t, 3, count())
+	})
+
+	t.Run("SUCCESS_DeletePets_BestEffort", func(t *testing.T) {
+		rslt := do(testutil.NewRequest().Delete("/pets?ids=3,100&mode=best_effort"))
+		assert.True(t, rslt.Committed)
+		assert.Equal(t, []int{http.StatusNoContent, http.StatusNotFound}, statuses(rslt))
+		assert.Equal(t, int64(100), rslt.Results[1].ID)
+		assert.Equal(t, 2, count())
+	})
+
+	t.Run("SUCCESS_DeletePets_Atomic", func(t *testing.T) {
+		rslt := do(testutil.NewRequest().Delete("/v2/pets?ids=1,2"))
+		assert.True(t, rslt.Committed)
+		assert.Equal(t, []int{http.StatusNoContent, http.StatusNoContent}, statuses(rslt))
+		assert.Equal(t, 0, count())
+	})
+
+	// abnormal, rolled back
+	t.Run("ABNORMAL_AddPets_Atomic", func(t *testing.T) {
+		rslt := do(testutil.NewRequest().Post("/pets:batch").WithJsonBody([]openapi.NewPet{popNewPet("qux", ""), popNewPet("", "")}))
+		assert.False(t, rslt.Committed)
+		assert.Equal(t, []int{http.StatusFailedDependency, http.StatusBadRequest}, statuses(rslt))
+		assert.Nil(t, rslt.Results[0].Pet)
+		assert.Equal(t, 0, count())
+	})
+
+	// abnormal, rolled back
+	t.Run("ABNORMAL_DeletePets_Atomic", func(t *testing.T) {
+		added := do(testutil.NewRequest().Post("/pets:batch").WithJsonBody([]openapi.NewPet{popNewPet("quux", "")}))
+		rslt := do(testutil.NewRequest().Delete(fmt.Sprintf("/pets?ids=%d,1", added.Results[0].ID)))
+		assert.False(t, rslt.Committed)
+		assert.Equal(t, []int{http.StatusFailedDependency, http.StatusNotFound}, statuses(rslt))
+		assert.Equal(t, 1, count())
+	})
+
+	t.Run("SUCCESS_DeletePets_Duplicate", func(t *testing.T) {
+		added := do(testutil.NewRequest().Post("/pets:batch").WithJsonBody([]openapi.NewPet{popNewPet("corge", "")}))
+		rslt := do(testutil.NewRequest().Delete(fmt.Sprintf("/pets?ids=%d,%d", added.Results[0].ID, added.Results[0].ID)))
+		assert.True(t, rslt.Committed)
+		assert.Equal(t, []int{http.StatusNoContent}, statuses(rslt))
+		assert.Equal(t, 1, count())
+	})
+
+	// abnormal 400
+	t.Run("ABNORMAL_Batch_BadRequest", func(t *testing.T) {
+		for _, req := range []*testutil.RequestBuilder{
+			testutil.NewRequest().Post("/pets:batch").WithJsonBody([]openapi.NewPet{}),
+			testutil.NewRequest().Post("/pets:batch?mode=some").WithJsonBody([]openapi.NewPet{popNewPet("foo", "")}),
+			testutil.NewRequest().Post("/pets:batch").WithJsonBody(popNewPet("foo", "")),
+			testutil.NewRequest().Post("/v2/pets:batch").WithJsonBody([]map[string]interface{}{{"name": "foo", "color": "red"}}),
+			testutil.NewRequest().Delete("/pets"),
+			testutil.NewRequest().Delete("/pets?ids=a"),
+		} {
+			rr := req.GoWithHTTPHandler(t, r).Recorder
+			assert.Equal(t, http.StatusBadRequest, rr.Code, rr.Body.String())
+		}
+	})
+}
+
+func TestTags(t *testing.T) {
+	// database
+	db := setupDB(t)
+	defer db.Close()
+
+	// handlers
+	cfg := config.Default()
+	cfg.ResponseValidation = "fail"
+	r, err := newRouter(db, &cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	add := func(body map[string]interface{}) map[string]interface{} {
+		rr := testutil.NewRequest().Post("/v2/pets").WithJsonBody(body).GoWithHTTPHandler(t, r).Recorder
+		assert.Equal(t, http.StatusCreated, rr.Code, rr.Body.String())
+		return decodeObject(t, rr.Body.Bytes())
+	}
+	names := func(url string) []string {
+		rslts := []string{}
+		list := openapiv2.PetList{}
+		json.NewDecoder(doGet(t, r, url).Body).Decode(&list)
+		for _, p := range list.Items {
+			rslts = append(rslts, p.Name)
+		}
+		return rslts
+	}
+	add(map[string]interface{}{"name": "foo", "tags": []string{"cat", "small", "cat"}})
+	add(map[string]interface{}{"name": "bar", "tag": "cat"})
+	add(map[string]interface{}{"name": "baz", "tags": []string{"dog", "small"}})
+	add(map[string]interface{}{"name": "qux"})
+
+	t.Run("SUCCESS_AddPet_Tags", func(t *testing.T) {
+		rr := doGet(t, r, "/v2/pets/1")
+		assert.JSONEq(t, `{"id":1,"name":"foo","status":"available","tag":"cat","tags":["cat","small"]}`, rr.Body.String())
+		rr = doGet(t, r, "/v2/pets/2")
+		assert.JSONEq(t, `{"id":2,"name":"bar","status":"available","tag":"cat","tags":["cat"]}`, rr.Body.String())
+	})
+
+	t.Run("SUCCESS_V1_Tag_Only", func(t *testing.T) {
+		rr := doGet(t, r, "/pets/3")
+		assert.JSONEq(t, `{"id":3,"name":"baz","tag":"dog"}`, rr.Body.String())
+	})
+
+	t.Run("SUCCESS_FindPets_TagMatch", func(t *testing.T) {
+		assert.Equal(t, []string{"foo", "bar", "baz"}, names("/v2/pets?tags=cat&tags=small"))
+		assert.Equal(t, []string{"foo", "bar", "baz"}, names("/v2/pets?tags=cat&tags=small&tag_match=any"))
+		assert.Equal(t, []string{"foo"}, names("/v2/pets?tags=cat&tags=small&tag_match=all"))
+		assert.Equal(t, []string{"qux"}, names("/v2/pets?has_tag=false"))
+	})
+
+	t.Run("SUCCESS_FindTags", func(t *testing.T) {
+		rr := doGet(t, r, "/v2/tags")
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.JSONEq(t, `{"items":[{"name":"cat","count":2},{"name":"dog","count":1},{"name":"small","count":2}]}`, rr.Body.String())
+		assert.NotEmpty(t, rr.Header().Get("ETag"))
+	})
+
+	t.Run("SUCCESS_PatchPet_Tag_Replaces_Tags", func(t *testing.T) {
+		rr := testutil.NewRequest().Patch("/v2/pets/3").WithJsonBody(map[string]interface{}{"tag": "puppy"}).GoWithHTTPHandler(t, r).Recorder
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.JSONEq(t, `{"id":3,"name":"baz","status":"available","tag":"puppy","tags":["puppy"]}`, rr.Body.String())
+
+		// dog is not used any more
+		rr = doGet(t, r, "/v2/tags")
+		assert.NotContains(t, rr.Body.String(), `"dog"`)
+	})
+
+	t.Run("SUCCESS_RenameTag", func(t *testing.T) {
+		etag := doGet(t, r, "/v2/pets/1").Header().Get("ETag")
+		rr := testutil.NewRequest().Post("/v2/tags:rename").WithJsonBody(map[string]string{"from": "small", "to": "tiny"}).GoWithHTTPHandler(t, r).Recorder
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.JSONEq(t, `{"name":"tiny","count":1}`, rr.Body.String())
+
+		rr = doGet(t, r, "/v2/pets/1")
+		assert.JSONEq(t, `{"id":1,"name":"foo","status":"available","tag":"cat","tags":["cat","tiny"]}`, rr.Body.String())
+		assert.NotEqual(t, etag, rr.Header().Get("ETag"))
+	})
+
+	t.Run("SUCCESS_MergeTags", func(t *testing.T) {
+		rr := testutil.NewRequest().Post("/tags:merge").WithJsonBody(map[string]interface{}{"from": []string{"tiny", "puppy"}, "into": "cat"}).GoWithHTTPHandler(t, r).Recorder
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.JSONEq(t, `{"name":"cat","count":3}`, rr.Body.String())
+
+		rr = doGet(t, r, "/v2/pets/1")
+		assert.JSONEq(t, `{"id":1,"name":"foo","status":"available","tag":"cat","tags":["cat"]}`, rr.Body.String())
+		rr = doGet(t, r, "/v2/tags")
+		assert.JSONEq(t, `{"items":[{"name":"cat","count":3}]}`, rr.Body.String())
+	})
+
+	// abnormal 400
+	t.Run("ABNORMAL_AddPet_Tag_Not_In_Tags", func(t *testing.T) {
+		rr := testutil.NewRequest().Post("/v2/pets").WithJsonBody(map[string]interface{}{"name": "foo", "tag": "a", "tags": []string{"b"}}).GoWithHTTPHandler(t, r).Recorder
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+		assert.Contains(t, rr.Body.String(), `"field":"tag"`)
+	})
+
+	// abnormal 400
+	t.Run("ABNORMAL_FindPets_TagMatch", func(t *testing.T) {
+		rr := doGet(t, r, "/v2/pets?tags=cat&tag_match=some")
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+	})
+
+	// abnormal 404
+	t.Run("ABNORMAL_RenameTag_NotFound", func(t *testing.T) {
+		rr := testutil.NewRequest().Post("/v2/tags:rename").WithJsonBody(map[string]string{"from": "none", "to": "new"}).GoWithHTTPHandler(t, r).Recorder
+		assert.Equal(t, http.StatusNotFound, rr.Code)
+
+		rr = testutil.NewRequest().Post("/v2/tags:merge").WithJsonBody(map[string]interface{}{"from": []string{"none"}, "into": "cat"}).GoWithHTTPHandler(t, r).Recorder
+		assert.Equal(t, http.StatusNotFound, rr.Code)
+	})
+
+	// abnormal 409
+	t.Run("ABNORMAL_RenameTag_Exists", func(t *testing.T) {
+		add(map[string]interface{}{"name": "quux", "tags": []string{"dog"}})
+		rr := testutil.NewRequest().Post("/v2/tags:rename").WithJsonBody(map[string]string{"from": "dog", "to": "cat"}).GoWithHTTPHandler(t, r).Recorder
+		assert.Equal(t, http.StatusConflict, rr.Code)
+	})
+
+	// abnormal 400
+	t.Run("ABNORMAL_Empty_Tag", func(t *testing.T) {
+		// an empty tag is no tag
+		p := add(map[string]interface{}{"name": "corge", "tag": ""})
+		assert.NotContains(t, p, "tag")
+		assert.NotContains(t, p, "tags")
+		p = add(map[string]interface{}{"name": "grault", "tags": []string{"", "cat"}})
+		assert.Equal(t, "cat", p["tag"])
+		assert.Equal(t, []interface{}{"cat"}, p["tags"])
+		rr := doGet(t, r, "/v2/tags")
+		assert.NotContains(t, rr.Body.String(), `"name":""`)
+
+		rr = testutil.NewRequest().Post("/v2/tags:rename").WithJsonBody(map[string]string{"from": "cat", "to": ""}).GoWithHTTPHandler(t, r).Recorder
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+		assert.Contains(t, rr.Body.String(), `"field":"to"`)
+		rr = testutil.NewRequest().Post("/v2/tags:merge").WithJsonBody(map[string]interface{}{"from": []string{"cat"}, "into": ""}).GoWithHTTPHandler(t, r).Recorder
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+		assert.Contains(t, rr.Body.String(), `"field":"into"`)
+	})
+}
+
+func TestSearch(t *testing.T) {
+	// database
+	db := setupDB(t)
+	defer db.Close()
+	if _, err := repository.SetupSearch(context.Background(), db); err != nil {
+		t.Fatal(err)
+	}
+
+	// handlers
+	cfg := config.Default()
+	cfg.ResponseValidation = "fail"
+	r, err := newRouter(db, &cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, body := range []map[string]interface{}{
+		{"name": "Fluffy", "tags": []string{"cat"}},
+		{"name": "Fluffy Rex", "tags": []string{"dog", "brown"}},
+		{"name": "Tom", "tags": []string{"cat"}},
+	} {
+		rr := testutil.NewRequest().Post("/v2/pets").WithJsonBody(body).GoWithHTTPHandler(t, r).Recorder
+		assert.Equal(t, http.StatusCreated, rr.Code, rr.Body.String())
+	}
+	search := func(url string) openapiv2.PetSearchResult {
+		rr := doGet(t, r, url)
+		assert.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+		rslt := openapiv2.PetSearchResult{}
+		json.NewDecoder(rr.Body).Decode(&rslt)
+		return rslt
+	}
+	names := func(rslt openapiv2.PetSearchResult) []string {
+		rslts := []string{}
+		for _, p := range rslt.Items {
+			rslts = append(rslts, p.Name)
+		}
+		return rslts
+	}
+
+	t.Run("SUCCESS_SearchPets", func(t *testing.T) {
+		assert.Equal(t, []string{"Fluffy", "Fluffy Rex"}, names(search("/v2/pets/search?q=fluffy")))
+		assert.Equal(t, []string{"Fluffy Rex"}, names(search("/v2/pets/search?q=fluffy%20brown")))
+		assert.ElementsMatch(t, []string{"Fluffy", "Tom"}, names(search("/v2/pets/search?q=cat")))
+		assert.Equal(t, []string{}, names(search("/v2/pets/search?q=none")))
+	})
+
+	t.Run("SUCCESS_SearchPets_Highlight", func(t *testing.T) {
+		rslt := search("/v2/pets/search?q=rex&highlight=true")
+		assert.Equal(t, "Fluffy <mark>Rex</mark>", *rslt.Items[0].Highlight)
+		assert.Equal(t, []string{"dog", "brown"}, *rslt.Items[0].Tags)
+
+		rslt = search("/v2/pets/search?q=rex")
+		assert.Nil(t, rslt.Items[0].Highlight)
+	})
+
+	t.Run("SUCCESS_SearchPets_Pages", func(t *testing.T) {
+		rr := doGet(t, r, "/v2/pets/search?q=fluffy&limit=1")
+		rslt := openapiv2.PetSearchResult{}
+		json.NewDecoder(rr.Body).Decode(&rslt)
+		assert.Equal(t, []string{"Fluffy"}, names(rslt))
+		assert.Equal(t, *rslt.NextCursor, rr.Header().Get("X-Next-Cursor"))
+
+		rslt = search("/v2/pets/search?q=fluffy&limit=1&cursor=" + *rslt.NextCursor)
+		assert.Equal(t, []string{"Fluffy Rex"}, names(rslt))
+		assert.Nil(t, rslt.NextCursor)
+	})
+
+	t.Run("SUCCESS_SearchPets_V1", func(t *testing.T) {
+		rr := doGet(t, r, "/pets/search?q=tom")
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.JSONEq(t, `{"items":[{"id":3,"name":"Tom","tag":"cat"}]}`, rr.Body.String())
+	})
+
+	// abnormal 400
+	t.Run("ABNORMAL_SearchPets_Blank", func(t *testing.T) {
+		rr := doGet(t, r, "/v2/pets/search?q=%20")
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+		assert.Contains(t, rr.Body.String(), `"field":"q"`)
+
+		rr = doGet(t, r, "/v2/pets/search")
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+	})
+
+	// abnormal 400
+	t.Run("ABNORMAL_SearchPets_Cursor", func(t *testing.T) {
+		rslt := search("/v2/pets/search?q=fluffy&limit=1")
+		rr := doGet(t, r, "/v2/pets/search?q=rex&cursor="+*rslt.NextCursor)
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+		assert.Contains(t, rr.Body.String(), `"field":"cursor"`)
+	})
+}
+
+func TestProfile(t *testing.T) {
+	// database
+	db := setupDB(t)
+	defer db.Close()
+
+	// handlers
+	cfg := config.Default()
+	cfg.ResponseValidation = "fail"
+	r, err := newRouter(db, &cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	add := func(body map[string]interface{}) *httptest.ResponseRecorder {
+		return testutil.NewRequest().Post("/v2/pets").WithJsonBody(body).GoWithHTTPHandler(t, r).Recorder
+	}
+	names := func(url string) []string {
+		rslts := []string{}
+		list := openapiv2.PetList{}
+		json.NewDecoder(doGet(t, r, url).Body).Decode(&list)
+		for _, p := range list.Items {
+			rslts = append(rslts, p.Name)
+		}
+		return rslts
+	}
+	add(map[string]interface{}{"name": "foo", "species": "Dog", "breed": "Shiba", "birth_date": "2020-01-02", "sex": "male",
+		"attributes": map[string]interface{}{"color": "brown", "chip": 123}})
+	add(map[string]interface{}{"name": "bar", "species": "cat", "birth_date": "2021-06-01", "sex": "female"})
+	add(map[string]interface{}{"name": "baz", "species": "dog", "status": "on_hold"})
+	for _, transition := range []string{"reserve", "adopt"} {
+		testutil.NewRequest().Post("/v2/pets/2/transitions").WithJsonBody(map[string]interface{}{"transition": transition, "actor": "alice"}).GoWithHTTPHandler(t, r)
+	}
+
+	t.Run("SUCCESS_AddPet_Profile", func(t *testing.T) {
+		rr := doGet(t, r, "/v2/pets/1")
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.JSONEq(t, `{"id":1,"name":"foo","species":"Dog","breed":"Shiba","birth_date":"2020-01-02","sex":"male","status":"available",
+			"attributes":{"color":"brown","chip":123}}`, rr.Body.String())
+	})
+
+	t.Run("SUCCESS_V1_Profile_Hidden", func(t *testing.T) {
+		rr := testutil.NewRequest().Post("/pets").WithJsonBody(map[string]interface{}{"name": "qux", "species": "bird", "status": "on_hold"}).GoWithHTTPHandler(t, r).Recorder
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.JSONEq(t, `{"id":4,"name":"qux"}`, rr.Body.String())
+		rr = doGet(t, r, "/pets/1")
+		assert.JSONEq(t, `{"id":1,"name":"foo"}`, rr.Body.String())
+
+		// written by v1
+		rr = doGet(t, r, "/v2/pets/4")
+		assert.JSONEq(t, `{"id":4,"name":"qux","species":"bird","status":"on_hold"}`, rr.Body.String())
+	})
+
+	t.Run("SUCCESS_FindPets_Profile", func(t *testing.T) {
+		assert.Equal(t, []string{"foo", "baz"}, names("/v2/pets?species=DOG"))
+		assert.Equal(t, []string{"foo"}, names("/v2/pets?breed=shiba"))
+		assert.Equal(t, []string{"bar"}, names("/v2/pets?sex=female"))
+		assert.Equal(t, []string{"foo", "bar"}, names("/v2/pets?status=available&status=adopted"))
+		assert.Equal(t, []string{"baz"}, names("/v2/pets?status=adopted&status=on_hold&species=dog"))
+		assert.Equal(t, []string{"foo", "bar"}, names("/v2/pets?born_after=2020-01-02"))
+		assert.Equal(t, []string{"foo"}, names("/v2/pets?born_after=2019-01-01&born_before=2021-05-31"))
+	})
+
+	t.Run("SUCCESS_PatchPet_Attributes", func(t *testing.T) {
+		patch := map[string]interface{}{"name": "Foo", "breed": nil, "attributes": map[string]interface{}{"chip": nil, "size": "small"}}
+		rr := testutil.NewRequest().Patch("/v2/pets/1").WithJsonBody(patch).GoWithHTTPHandler(t, r).Recorder
+		assert.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+		assert.JSONEq(t, `{"id":1,"name":"Foo","species":"Dog","birth_date":"2020-01-02","sex":"male","status":"available",
+			"attributes":{"color":"brown","size":"small"}}`, rr.Body.String())
+
+		rr = testutil.NewRequest().Patch("/v2/pets/1").WithJsonBody(map[string]interface{}{"attributes": nil}).GoWithHTTPHandler(t, r).Recorder
+		assert.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+		assert.NotContains(t, rr.Body.String(), `"attributes"`)
+	})
+
+	t.Run("SUCCESS_UpdatePet_Clears_Profile_Keeps_Status", func(t *testing.T) {
+		rr := testutil.NewRequest().Put("/v2/pets/2").WithJsonBody(map[string]interface{}{"name": "bar"}).GoWithHTTPHandler(t, r).Recorder
+		assert.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+		assert.JSONEq(t, `{"id":2,"name":"bar","status":"adopted"}`, rr.Body.String())
+	})
+
+	// abnormal 400
+	t.Run("ABNORMAL_AddPet_Profile", func(t *testing.T) {
+		attributes := map[string]interface{}{}
+		for i := 0; i < 51; i++ {
+			attributes[fmt.Sprintf("k%d", i)] = i
+		}
+		for field, body := range map[string]map[string]interface{}{
+			"sex":        {"name": "foo", "sex": "none"},
+			"status":     {"name": "foo", "status": "sold"},
+			"species":    {"name": "foo", "species": strings.Repeat("a", 51)},
+			"birth_date": {"name": "foo", "birth_date": time.Now().AddDate(0, 0, 2).Format("2006-01-02")},
+			"attributes": {"name": "foo", "attributes": attributes},
+		} {
+			rr := add(body)
+			assert.Equal(t, http.StatusBadRequest, rr.Code, field)
+			// by the spec or the usecase
+			assert.Contains(t, rr.Body.String(), field, field)
+		}
+	})
+
+	// abnormal 400
+	t.Run("ABNORMAL_PatchPet_Status_Removed", func(t *testing.T) {
+		rr := testutil.NewRequest().Patch("/v2/pets/3").WithJsonBody(map[string]interface{}{"status": nil}).GoWithHTTPHandler(t, r).Recorder
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+		assert.Contains(t, rr.Body.String(), `/status`)
+	})
+
+	// abnormal 400
+	t.Run("ABNORMAL_FindPets_Profile", func(t *testing.T) {
+		rr := doGet(t, r, "/v2/pets?status=sold")
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+		rr = doGet(t, r, "/v2/pets?born_after=2021-01-01&born_before=2020-01-01")
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+		assert.Contains(t, rr.Body.String(), `"field":"born_before"`)
+	})
+}
+
+func TestTransitions(t *testing.T) {
+	// database
+	db := setupDB(t)
+	defer db.Close()
+
+	// handlers
+	cfg := config.Default()
+	cfg.ResponseValidation = "fail"
+	r, err := newRouter(db, &cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	transition := func(url string, transition string, actor string) *httptest.ResponseRecorder {
+		body := map[string]interface{}{"transition": transition, "actor": actor}
+		return testutil.NewRequest().Post(url).WithJsonBody(body).GoWithHTTPHandler(t, r).Recorder
+	}
+	for _, name := range []string{"foo", "bar"} {
+		testutil.NewRequest().Post("/v2/pets").WithJsonBody(map[string]interface{}{"name": name}).GoWithHTTPHandler(t, r)
+	}
+
+	t.Run("SUCCESS_TransitionPet", func(t *testing.T) {
+		etag := doGet(t, r, "/v2/pets/1").Header().Get("ETag")
+		rr := transition("/v2/pets/1/transitions", "reserve", "alice")
+		assert.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+		assert.JSONEq(t, `{"id":1,"name":"foo","status":"reserved"}`, rr.Body.String())
+		assert.NotEqual(t, etag, rr.Header().Get("ETag"))
+
+		rr = transition("/v2/pets/1/transitions", "adopt", "bob")
+		assert.JSONEq(t, `{"id":1,"name":"foo","status":"adopted"}`, rr.Body.String())
+		rr = transition("/v2/pets/1/transitions", "return", "bob")
+		assert.JSONEq(t, `{"id":1,"name":"foo","status":"available"}`, rr.Body.String())
+	})
+
+	t.Run("SUCCESS_FindPetTransitions", func(t *testing.T) {
+		rr := doGet(t, r, "/v2/pets/1/transitions")
+		assert.Equal(t, http.StatusOK, rr.Code)
+		list := openapiv2.PetTransitionList{}
+		json.Unmarshal(rr.Body.Bytes(), &list)
+		if assert.Len(t, list.Items, 3) {
+			assert.Equal(t, openapiv2.PetTransition{Transition: "reserve", From: "available", To: "reserved", Actor: "alice", CreatedAt: list.Items[0].CreatedAt}, list.Items[0])
+			assert.Equal(t, "adopt", list.Items[1].Transition)
+			assert.Equal(t, "return", list.Items[2].Transition)
+			assert.WithinDuration(t, time.Now(), list.Items[2].CreatedAt, time.Minute)
+		}
+
+		rr = doGet(t, r, "/v2/pets/2/transitions")
+		assert.JSONEq(t, `{"items":[]}`, rr.Body.String())
+	})
+
+	t.Run("SUCCESS_TransitionPet_V1", func(t *testing.T) {
+		rr := transition("/pets/2/transitions", "hold", "alice")
+		assert.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+		assert.JSONEq(t, `{"id":2,"name":"bar"}`, rr.Body.String())
+
+		rr = transition("/pets/2/transitions", "release", "alice")
+		assert.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+	})
+
+	// abnormal 409
+	t.Run("ABNORMAL_TransitionPet_Not_Allowed", func(t *testing.T) {
+		transition("/v2/pets/2/transitions", "reserve", "alice")
+		rr := transition("/v2/pets/2/transitions", "reserve", "bob")
+		assert.Equal(t, http.StatusConflict, rr.Code)
+		problem := openapiv2.Problem{}
+		json.Unmarshal(rr.Body.Bytes(), &problem)
+		assert.Equal(t, "transition_not_allowed", *problem.ErrorCode)
+		assert.Equal(t, []string{"adopt", "cancel"}, *problem.AllowedTransitions)
+
+		// status changes only by transitions
+		rr = testutil.NewRequest().Patch("/v2/pets/2").WithJsonBody(map[string]interface{}{"status": "available"}).GoWithHTTPHandler(t, r).Recorder
+		assert.Equal(t, http.StatusConflict, rr.Code)
+		assert.Contains(t, rr.Body.String(), `"allowed_transitions":["adopt","cancel"]`)
+	})
+
+	// abnormal 409
+	t.Run("ABNORMAL_DeletePet_Reserved", func(t *testing.T) {
+		rr := testutil.NewRequest().Delete("/v2/pets/2").GoWithHTTPHandler(t, r).Recorder
+		assert.Equal(t, http.StatusConflict, rr.Code)
+		assert.Contains(t, rr.Body.String(), `"allowed_transitions":["adopt","cancel"]`)
+
+		// the reserved pet fails, the others are deleted
+		testutil.NewRequest().Post("/v2/pets").WithJsonBody(map[string]interface{}{"name": "baz"}).GoWithHTTPHandler(t, r)
+		rr = testutil.NewRequest().Delete("/v2/pets?ids=2,3&mode=best_effort").GoWithHTTPHandler(t, r).Recorder
+		assert.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+		rslt := openapiv2.BatchResult{}
+		json.Unmarshal(rr.Body.Bytes(), &rslt)
+		assert.True(t, rslt.Committed)
+		if assert.Len(t, rslt.Results, 2) {
+			assert.Equal(t, http.StatusConflict, rslt.Results[0].Status)
+			assert.Equal(t, []string{"adopt", "cancel"}, *rslt.Results[0].Error.AllowedTransitions)
+			assert.Equal(t, http.StatusNoContent, rslt.Results[1].Status)
+		}
+		assert.Equal(t, http.StatusNotFound, doGet(t, r, "/v2/pets/3").Code)
+
+		// atomic rolls back the others
+		testutil.NewRequest().Post("/v2/pets").WithJsonBody(map[string]interface{}{"name": "qux"}).GoWithHTTPHandler(t, r)
+		rr = testutil.NewRequest().Delete("/v2/pets?ids=4,2").GoWithHTTPHandler(t, r).Recorder
+		assert.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+		rslt = openapiv2.BatchResult{}
+		json.Unmarshal(rr.Body.Bytes(), &rslt)
+		assert.False(t, rslt.Committed)
+		if assert.Len(t, rslt.Results, 2) {
+			assert.Equal(t, http.StatusFailedDependency, rslt.Results[0].Status)
+			assert.Equal(t, http.StatusConflict, rslt.Results[1].Status)
+		}
+		assert.Equal(t, http.StatusOK, doGet(t, r, "/v2/pets/4").Code)
+
+		transition("/v2/pets/2/transitions", "cancel", "alice")
+		rr = testutil.NewRequest().Delete("/v2/pets/2").GoWithHTTPHandler(t, r).Recorder
+		assert.Equal(t, http.StatusNoContent, rr.Code)
+	})
+
+	// abnormal 400
+	t.Run("ABNORMAL_TransitionPet_Invalid", func(t *testing.T) {
+		rr := transition("/v2/pets/1/transitions", "sell", "alice")
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+		rr = transition("/v2/pets/1/transitions", "hold", " ")
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+		assert.Contains(t, rr.Body.String(), `"field":"actor"`)
+
+		// new pets are available or on_hold
+		rr = testutil.NewRequest().Post("/v2/pets").WithJsonBody(map[string]interface{}{"name": "baz", "status": "adopted"}).GoWithHTTPHandler(t, r).Recorder
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+		assert.Contains(t, rr.Body.String(), `"field":"status"`)
+	})
+
+	// abnormal 404
+	t.Run("ABNORMAL_TransitionPet_NotFound", func(t *testing.T) {
+		rr := transition("/v2/pets/100/transitions", "hold", "alice")
+		assert.Equal(t, http.StatusNotFound, rr.Code)
+		rr = doGet(t, r, "/v2/pets/100/transitions")
+		assert.Equal(t, http.StatusNotFound, rr.Code)
+	})
+
+	// abnormal 412
+	t.Run("ABNORMAL_TransitionPet_IfMatch", func(t *testing.T) {
+		rr := testutil.NewRequest().Post("/v2/pets/1/transitions").WithHeader("If-Match", `"1"`).
+			WithJsonBody(map[string]interface{}{"transition": "hold", "actor": "alice"}).GoWithHTTPHandler(t, r).Recorder
+		assert.Equal(t, http.StatusPreconditionFailed, rr.Code)
+	})
+}
+
+func TestOwners(t *testing.T) {
+	// database
+	db := setupDB(t)
+	defer db.Close()
+
+	// handlers
+	cfg := config.Default()
+	cfg.ResponseValidation = "fail"
+	r, err := newRouter(db, &cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	post := func(url string, body map[string]interface{}) *httptest.ResponseRecorder {
+		return testutil.NewRequest().Post(url).WithJsonBody(body).GoWithHTTPHandler(t, r).Recorder
+	}
+	adopt := func(petID int, ownerID int) *httptest.ResponseRecorder {
+		return post("/v2/adoptions", map[string]interface{}{"pet_id": petID, "owner_id": ownerID, "actor": "alice"})
+	}
+	for _, name := range []string{"foo", "bar", "baz"} {
+		post("/v2/pets", map[string]interface{}{"name": name})
+	}
+
+	t.Run("SUCCESS_AddOwner", func(t *testing.T) {
+		rr := post("/v2/owners", map[string]interface{}{"name": "alice", "email": "alice@example.com"})
+		assert.Equal(t, http.StatusCreated, rr.Code, rr.Body.String())
+		assert.Equal(t, "/v2/owners/1", rr.Header().Get("Location"))
+		o := openapiv2.Owner{}
+		json.Unmarshal(rr.Body.Bytes(), &o)
+		assert.Equal(t, int64(1), o.Id)
+		assert.Equal(t, "alice@example.com", o.Email)
+		assert.WithinDuration(t, time.Now(), o.CreatedAt, time.Minute)
+
+		post("/v2/owners", map[string]interface{}{"name": "bob", "email": "bob@example.com", "phone": "+81-3-0000-0000"})
+		post("/v2/owners", map[string]interface{}{"name": "carol", "email": "carol@example.org"})
+	})
+
+	t.Run("SUCCESS_FindOwnerById", func(t *testing.T) {
+		rr := doGet(t, r, "/v2/owners/2")
+		assert.Equal(t, http.StatusOK, rr.Code)
+		o := openapiv2.Owner{}
+		json.Unmarshal(rr.Body.Bytes(), &o)
+		assert.Equal(t, "bob", o.Name)
+		assert.Equal(t, "+81-3-0000-0000", *o.Phone)
+	})
+
+	t.Run("SUCCESS_FindOwners_Pages", func(t *testing.T) {
+		rr := doGet(t, r, "/v2/owners?limit=2")
+		assert.Equal(t, http.StatusOK, rr.Code)
+		list := openapiv2.OwnerList{}
+		json.Unmarshal(rr.Body.Bytes(), &list)
+		if assert.Len(t, list.Items, 2) && assert.NotNil(t, list.NextCursor) {
+			assert.Equal(t, *list.NextCursor, rr.Header().Get("X-Next-Cursor"))
+			assert.Contains(t, rr.Header().Get("Link"), `rel="next"`)
+
+			rr = doGet(t, r, "/v2/owners?limit=2&cursor="+*list.NextCursor)
+			list = openapiv2.OwnerList{}
+			json.Unmarshal(rr.Body.Bytes(), &list)
+			if assert.Len(t, list.Items, 1) {
+				assert.Equal(t, "carol", list.Items[0].Name)
+			}
+			assert.Nil(t, list.NextCursor)
+		}
+	})
+
+	t.Run("SUCCESS_UpdateOwner", func(t *testing.T) {
+		rr := testutil.NewRequest().Put("/v2/owners/3").WithJsonBody(map[string]interface{}{"name": "carol", "email": "carol@example.com"}).GoWithHTTPHandler(t, r).Recorder
+		assert.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+		assert.Contains(t, rr.Body.String(), `"email":"carol@example.com"`)
+		assert.Contains(t, rr.Body.String(), `"created_at"`)
+	})
+
+	t.Run("SUCCESS_AdoptPet", func(t *testing.T) {
+		post("/v2/pets/1/transitions", map[string]interface{}{"transition": "reserve", "actor": "alice"})
+		rr := adopt(1, 1)
+		assert.Equal(t, http.StatusCreated, rr.Code, rr.Body.String())
+		assert.Equal(t, "/v2/adoptions/1", rr.Header().Get("Location"))
+		a := openapiv2.Adoption{}
+		json.Unmarshal(rr.Body.Bytes(), &a)
+		assert.Equal(t, openapiv2.Adoption{Id: 1, PetId: 1, OwnerId: 1, Actor: "alice", CreatedAt: a.CreatedAt}, a)
+
+		rr = doGet(t, r, "/v2/adoptions/1")
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Contains(t, rr.Body.String(), `"owner_id":1`)
+
+		rr = doGet(t, r, "/v2/pets/1")
+		assert.Contains(t, rr.Body.String(), `"status":"adopted"`)
+		rr = doGet(t, r, "/v2/pets/1/transitions")
+		assert.Contains(t, rr.Body.String(), `"transition":"adopt"`)
+	})
+
+	t.Run("SUCCESS_FindOwnerPets", func(t *testing.T) {
+		rr := doGet(t, r, "/v2/owners/1/pets")
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.JSONEq(t, `{"items":[{"id":1,"name":"foo","status":"adopted"}]}`, rr.Body.String())
+
+		rr = doGet(t, r, "/v2/owners/2/pets")
+		assert.JSONEq(t, `{"items":[]}`, rr.Body.String())
+	})
+
+	// abnormal 409
+	t.Run("ABNORMAL_DeleteOwner_With_Pets_In_Trash", func(t *testing.T) {
+		post("/v2/pets/3/transitions", map[string]interface{}{"transition": "reserve", "actor": "alice"})
+		assert.Equal(t, http.StatusCreated, adopt(3, 2).Code)
+		rr := testutil.NewRequest().Delete("/v2/pets/3").GoWithHTTPHandler(t, r).Recorder
+		assert.Equal(t, http.StatusNoContent, rr.Code)
+		rr = doGet(t, r, "/v2/owners/2/pets")
+		assert.JSONEq(t, `{"items":[]}`, rr.Body.String())
+
+		// the pet may be restored
+		rr = testutil.NewRequest().Delete("/v2/owners/2").GoWithHTTPHandler(t, r).Recorder
+		assert.Equal(t, http.StatusConflict, rr.Code)
+		rr = testutil.NewRequest().Post("/v2/pets/3:restore").GoWithHTTPHandler(t, r).Recorder
+		assert.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+		rr = doGet(t, r, "/v2/owners/2/pets")
+		assert.Contains(t, rr.Body.String(), `"id":3`)
+	})
+
+	// abnormal 409
+	t.Run("ABNORMAL_DeleteOwner_With_Pets", func(t *testing.T) {
+		rr := testutil.NewRequest().Delete("/v2/owners/1").GoWithHTTPHandler(t, r).Recorder
+		assert.Equal(t, http.StatusConflict, rr.Code)
+
+		// returned pets are no longer owned
+		post("/v2/pets/1/transitions", map[string]interface{}{"transition": "return", "actor": "alice"})
+		rr = doGet(t, r, "/v2/owners/1/pets")
+		assert.JSONEq(t, `{"items":[]}`, rr.Body.String())
+		rr = testutil.NewRequest().Delete("/v2/owners/1").GoWithHTTPHandler(t, r).Recorder
+		assert.Equal(t, http.StatusNoContent, rr.Code)
+		// adoptions are deleted with the owner
+		assert.Equal(t, http.StatusNotFound, doGet(t, r, "/v2/adoptions/1").Code)
+	})
+
+	// abnormal 409
+	t.Run("ABNORMAL_AdoptPet_Not_Reserved", func(t *testing.T) {
+		rr := adopt(2, 2)
+		assert.Equal(t, http.StatusConflict, rr.Code)
+		assert.Contains(t, rr.Body.String(), `"allowed_transitions":["reserve","hold"]`)
+
+		rr = post("/v2/owners", map[string]interface{}{"name": "dave", "email": "CAROL@example.com"})
+		assert.Equal(t, http.StatusConflict, rr.Code)
+	})
+
+	// abnormal 422
+	t.Run("ABNORMAL_AdoptPet_Unknown", func(t *testing.T) {
+		rr := adopt(100, 2)
+		assert.Equal(t, http.StatusUnprocessableEntity, rr.Code)
+		assert.Contains(t, rr.Body.String(), `"field":"pet_id"`)
+		rr = adopt(2, 100)
+		assert.Equal(t, http.StatusUnprocessableEntity, rr.Code)
+		assert.Contains(t, rr.Body.String(), `"field":"owner_id"`)
+	})
+
+	// abnormal 400
+	t.Run("ABNORMAL_AddOwner_Invalid", func(t *testing.T) {
+		rr := post("/v2/owners", map[string]interface{}{"name": "dave", "email": "dave"})
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+		assert.Contains(t, rr.Body.String(), `"field":"email"`)
+		rr = post("/v2/owners", map[string]interface{}{"name": "", "email": "dave@example.com"})
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+		rr = doGet(t, r, "/v2/owners?cursor=xyz")
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+		assert.Contains(t, rr.Body.String(), `"field":"cursor"`)
+		rr = doGet(t, r, "/v2/adoptions/-1")
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+		assert.Contains(t, rr.Body.String(), `"field":"id"`)
+	})
+
+	// abnormal 404
+	t.Run("ABNORMAL_Owner_NotFound", func(t *testing.T) {
+		assert.Equal(t, http.StatusNotFound, doGet(t, r, "/v2/owners/100").Code)
+		assert.Equal(t, http.StatusNotFound, doGet(t, r, "/v2/owners/100/pets").Code)
+		rr := testutil.NewRequest().Put("/v2/owners/100").WithJsonBody(map[string]interface{}{"name": "dave", "email": "dave@example.com"}).GoWithHTTPHandler(t, r).Recorder
+		assert.Equal(t, http.StatusNotFound, rr.Code)
+		rr = testutil.NewRequest().Delete("/v2/owners/100").GoWithHTTPHandler(t, r).Recorder
+		assert.Equal(t, http.StatusNotFound, rr.Code)
+		assert.Equal(t, http.StatusNotFound, doGet(t, r, "/v2/adoptions/100").Code)
+		// owners are v2 only
+		assert.Equal(t, http.StatusNotFound, doGet(t, r, "/owners/2").Code)
+	})
+}
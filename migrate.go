@@ -0,0 +1,52 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"text/tabwriter"
+
+	"github.com/opbls/scapo/petstore/migration"
+)
+
+// runMigrate runs migrate subcommand.
+//   - migrate up: apply pending migrations
+//   - migrate down: revert the latest migration
+//   - migrate status: list migrations and applied time
+func runMigrate(ctx context.Context, m migration.Migrator, args []string) error {
+	if len(args) != 1 {
+		return fmt.Errorf("usage: migrate up|down|status")
+	}
+
+	switch args[0] {
+	case "up":
+		applied, err := m.Up(ctx)
+		for _, a := range applied {
+			fmt.Printf("applied %04d_%s\n", a.Version, a.Name)
+		}
+		return err
+	case "down":
+		reverted, err := m.Down(ctx)
+		if reverted != nil {
+			fmt.Printf("reverted %04d_%s\n", reverted.Version, reverted.Name)
+		}
+		return err
+	case "status":
+		status, err := m.Status(ctx)
+		if err != nil {
+			return err
+		}
+		w := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+		fmt.Fprintln(w, "VERSION\tNAME\tAPPLIED AT")
+		for _, s := range status {
+			at := "pending"
+			if s.AppliedAt != nil {
+				at = s.AppliedAt.Format("2006-01-02 15:04:05")
+			}
+			fmt.Fprintf(w, "%04d\t%s\t%s\n", s.Version, s.Name, at)
+		}
+		return w.Flush()
+	default:
+		return fmt.Errorf("usage: migrate up|down|status")
+	}
+}
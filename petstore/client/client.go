@@ -0,0 +1,204 @@
+// Package client is the Go client of the petstore API.
+//
+// Requests and responses are the generated openapi.ClientWithResponses,
+// this package adds typed results, errors and retries.
+package client
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/opbls/scapo/petstore/openapi"
+)
+
+type (
+	// PetStoreClient interface.
+	PetStoreClient interface {
+		FindPets(ctx context.Context, params *openapi.FindPetsParams) (*Pets, error)
+		AddPet(ctx context.Context, np openapi.NewPet) (*openapi.Pet, error)
+		DeletePet(ctx context.Context, id int64) error
+		FindPetById(ctx context.Context, id int64) (*openapi.Pet, error)
+	}
+
+	// PetStoreClientImpl struct.
+	PetStoreClientImpl struct {
+		Client openapi.ClientWithResponsesInterface
+		// IdempotencyKeys sends a new Idempotency-Key on each AddPet.
+		IdempotencyKeys bool
+	}
+
+	// Pets is a page of FindPets.
+	Pets struct {
+		Pets []openapi.Pet
+		// NextCursor is the cursor of the next page, empty on the last page.
+		NextCursor string
+	}
+
+	// Option configures PetStoreClient.
+	Option func(*options)
+
+	options struct {
+		doer            openapi.HttpRequestDoer
+		editors         []openapi.RequestEditorFn
+		retry           Retry
+		idempotencyKeys bool
+	}
+)
+
+// NewPetStoreClient returns PetStoreClient of the API at server, like http://localhost:18080.
+func NewPetStoreClient(server string, opts ...Option) (PetStoreClient, error) {
+	o := options{doer: http.DefaultClient}
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	clientOpts := []openapi.ClientOption{
+		openapi.WithHTTPClient(&retryDoer{Doer: o.doer, Retry: o.retry}),
+	}
+	for _, fn := range o.editors {
+		clientOpts = append(clientOpts, openapi.WithRequestEditorFn(fn))
+	}
+	c, err := openapi.NewClientWithResponses(server, clientOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return &PetStoreClientImpl{
+		Client:          c,
+		IdempotencyKeys: o.idempotencyKeys,
+	}, nil
+}
+
+// WithHTTPClient sends requests by doer instead of http.DefaultClient.
+func WithHTTPClient(doer openapi.HttpRequestDoer) Option {
+	return func(o *options) {
+		o.doer = doer
+	}
+}
+
+// WithRequestEditor edits every request before sent, to add headers for example.
+func WithRequestEditor(fn openapi.RequestEditorFn) Option {
+	return func(o *options) {
+		o.editors = append(o.editors, fn)
+	}
+}
+
+// WithRetry retries failed requests, see Retry.
+func WithRetry(maxRetries int, backoff time.Duration) Option {
+	return func(o *options) {
+		o.retry = Retry{MaxRetries: maxRetries, Backoff: backoff}
+	}
+}
+
+// WithIdempotencyKeys sends a new Idempotency-Key on each AddPet, so that Retry retries it as well.
+func WithIdempotencyKeys() Option {
+	return func(o *options) {
+		o.idempotencyKeys = true
+	}
+}
+
+// FindPets Impl.
+func (impl *PetStoreClientImpl) FindPets(ctx context.Context, params *openapi.FindPetsParams) (*Pets, error) {
+	if params == nil {
+		params = &openapi.FindPetsParams{}
+	}
+	res, err := impl.Client.FindPetsWithResponse(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+	if res.JSON200 == nil {
+		return nil, responseError(res.HTTPResponse, res.JSONDefault)
+	}
+	return &Pets{
+		Pets:       *res.JSON200,
+		NextCursor: res.HTTPResponse.Header.Get("X-Next-Cursor"),
+	}, nil
+}
+
+// AddPet Impl.
+// With IdempotencyKeys each call has a new Idempotency-Key, so that retries never create the pet twice.
+func (impl *PetStoreClientImpl) AddPet(ctx context.Context, np openapi.NewPet) (*openapi.Pet, error) {
+	params := &openapi.AddPetParams{}
+	if impl.IdempotencyKeys {
+		key, err := newIdempotencyKey()
+		if err != nil {
+			return nil, err
+		}
+		params.IdempotencyKey = &key
+	}
+	res, err := impl.Client.AddPetWithResponse(ctx, params, openapi.AddPetJSONRequestBody(np))
+	if err != nil {
+		return nil, err
+	}
+	if res.JSON200 == nil {
+		return nil, responseError(res.HTTPResponse, res.JSON400, res.JSON409, res.JSON413, res.JSON422, res.JSONDefault)
+	}
+	return res.JSON200, nil
+}
+
+// DeletePet Impl.
+func (impl *PetStoreClientImpl) DeletePet(ctx context.Context, id int64) error {
+	res, err := impl.Client.DeletePetWithResponse(ctx, id)
+	if err != nil {
+		return err
+	}
+	if res.StatusCode() != http.StatusNoContent {
+		return responseError(res.HTTPResponse, res.JSON412, res.JSONDefault)
+	}
+	return nil
+}
+
+// FindPetById Impl.
+func (impl *PetStoreClientImpl) FindPetById(ctx context.Context, id int64) (*openapi.Pet, error) {
+	res, err := impl.Client.FindPetByIdWithResponse(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if res.JSON200 == nil {
+		return nil, responseError(res.HTTPResponse, res.JSONDefault)
+	}
+	return res.JSON200, nil
+}
+
+// newIdempotencyKey returns a random Idempotency-Key.
+func newIdempotencyKey() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
+
+// Error is the error response of the API.
+type Error struct {
+	// Body is the error of the response, made of the status when the body is not JSON.
+	Body openapi.Error
+	// StatusCode is HTTP status code.
+	StatusCode int
+	// Problem is the whole body, nil unless the body is JSON.
+	Problem *openapi.Problem
+}
+
+// Error Impl.
+func (e *Error) Error() string {
+	return fmt.Sprintf("petstore: %d %s", e.Body.Code, e.Body.Message)
+}
+
+// responseError returns Error of res, problems are the parsed body by status, nil unless matched.
+func responseError(res *http.Response, problems ...*openapi.Problem) error {
+	for _, problem := range problems {
+		if problem != nil {
+			return &Error{Body: problem.Error, StatusCode: res.StatusCode, Problem: problem}
+		}
+	}
+	return &Error{
+		Body: openapi.Error{
+			Code:    int32(res.StatusCode),
+			Message: http.StatusText(res.StatusCode),
+		},
+		StatusCode: res.StatusCode,
+	}
+}
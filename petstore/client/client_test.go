@@ -0,0 +1,271 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/jmoiron/sqlx"
+	// sqlite driver
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/opbls/scapo/petstore/delivery"
+	"github.com/opbls/scapo/petstore/migration"
+	"github.com/opbls/scapo/petstore/openapi"
+	"github.com/opbls/scapo/petstore/repository"
+	"github.com/opbls/scapo/petstore/usecase"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPetStoreClient(t *testing.T) {
+	ctx := context.Background()
+	server, requests := newServer(t)
+	defer server.Close()
+
+	c, err := NewPetStoreClient(server.URL, WithRequestEditor(func(ctx context.Context, req *http.Request) error {
+		req.Header.Set("X-Request-Id", "test")
+		return nil
+	}))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tag := "bar"
+	added := []openapi.Pet{}
+	for _, name := range []string{"foo", "baz", "qux"} {
+		p, err := c.AddPet(ctx, openapi.NewPet{Name: name, Tag: &tag})
+		if !assert.NoError(t, err) {
+			return
+		}
+		assert.Equal(t, name, p.Name)
+		added = append(added, *p)
+	}
+
+	t.Run("SUCCESS_FindPetById", func(t *testing.T) {
+		p, err := c.FindPetById(ctx, added[0].Id)
+		assert.NoError(t, err)
+		assert.Equal(t, added[0], *p)
+	})
+
+	t.Run("SUCCESS_FindPets_Pages", func(t *testing.T) {
+		limit := int32(2)
+		params := &openapi.FindPetsParams{Limit: &limit}
+		rslt, err := c.FindPets(ctx, params)
+		assert.NoError(t, err)
+		assert.Equal(t, added[:2], rslt.Pets)
+		if !assert.NotEmpty(t, rslt.NextCursor) {
+			return
+		}
+
+		params.Cursor = &rslt.NextCursor
+		rslt, err = c.FindPets(ctx, params)
+		assert.NoError(t, err)
+		assert.Equal(t, added[2:], rslt.Pets)
+		assert.Empty(t, rslt.NextCursor)
+	})
+
+	t.Run("SUCCESS_RequestEditor", func(t *testing.T) {
+		_, err := c.FindPets(ctx, nil)
+		assert.NoError(t, err)
+		assert.Equal(t, "test", requests()[len(requests())-1].Header.Get("X-Request-Id"))
+	})
+
+	t.Run("SUCCESS_DeletePet", func(t *testing.T) {
+		err := c.DeletePet(ctx, added[2].Id)
+		assert.NoError(t, err)
+
+		_, err = c.FindPetById(ctx, added[2].Id)
+		assert.Error(t, err)
+	})
+
+	// abnormal 404
+	t.Run("ABNORMAL_FindPetById_NotFound", func(t *testing.T) {
+		_, err := c.FindPetById(ctx, 10000)
+		e := &Error{}
+		if !assert.True(t, errors.As(err, &e)) {
+			return
+		}
+		assert.Equal(t, int32(http.StatusNotFound), e.Body.Code)
+		assert.Equal(t, http.StatusNotFound, e.StatusCode)
+		assert.Equal(t, "not_found", *e.Problem.ErrorCode)
+	})
+
+	// abnormal 404
+	t.Run("ABNORMAL_DeletePet_NotFound", func(t *testing.T) {
+		err := c.DeletePet(ctx, 10000)
+		e := &Error{}
+		if assert.True(t, errors.As(err, &e)) {
+			assert.Equal(t, int32(http.StatusNotFound), e.Body.Code)
+		}
+	})
+
+	// abnormal 400
+	t.Run("ABNORMAL_AddPet_Invalid", func(t *testing.T) {
+		_, err := c.AddPet(ctx, openapi.NewPet{Name: ""})
+		e := &Error{}
+		if assert.True(t, errors.As(err, &e)) {
+			assert.Equal(t, int32(http.StatusBadRequest), e.Body.Code)
+			assert.NotEmpty(t, *e.Problem.Violations)
+		}
+	})
+
+	// abnormal
+	t.Run("ABNORMAL_Unreachable", func(t *testing.T) {
+		c, _ := NewPetStoreClient("http://127.0.0.1:1")
+		_, err := c.FindPets(ctx, nil)
+		assert.Error(t, err)
+		assert.False(t, errors.As(err, new(*Error)))
+	})
+}
+
+func TestRetry(t *testing.T) {
+	ctx := context.Background()
+	server, _ := newServer(t)
+	defer server.Close()
+
+	t.Run("SUCCESS_Retry_Unavailable", func(t *testing.T) {
+		doer := &flakyDoer{Fails: 2}
+		c, _ := NewPetStoreClient(server.URL, WithHTTPClient(doer), WithRetry(2, time.Millisecond))
+		_, err := c.FindPets(ctx, nil)
+		assert.NoError(t, err)
+		assert.Equal(t, 3, doer.Calls)
+	})
+
+	// abnormal 503
+	t.Run("ABNORMAL_Retry_Exhausted", func(t *testing.T) {
+		doer := &flakyDoer{Fails: 3}
+		c, _ := NewPetStoreClient(server.URL, WithHTTPClient(doer), WithRetry(2, time.Millisecond))
+		_, err := c.FindPets(ctx, nil)
+		e := &Error{}
+		if assert.True(t, errors.As(err, &e)) {
+			assert.Equal(t, http.StatusServiceUnavailable, e.StatusCode)
+		}
+		assert.Equal(t, 3, doer.Calls)
+	})
+
+	t.Run("SUCCESS_Retry_PostIdempotencyKey", func(t *testing.T) {
+		doer := &lostResponseDoer{Losts: 1}
+		c, _ := NewPetStoreClient(server.URL, WithHTTPClient(doer), WithRetry(2, time.Millisecond), WithIdempotencyKeys())
+		p, err := c.AddPet(ctx, openapi.NewPet{Name: "lost"})
+		if !assert.NoError(t, err) {
+			return
+		}
+		assert.Equal(t, "lost", p.Name)
+		assert.Equal(t, 2, doer.Calls)
+
+		// the pet is created once
+		pets, err := c.FindPets(ctx, &openapi.FindPetsParams{NamePrefix: strp("lost")})
+		assert.NoError(t, err)
+		assert.Equal(t, 1, len(pets.Pets))
+	})
+
+	t.Run("SUCCESS_Retry_PostIdempotencyKey_Unavailable", func(t *testing.T) {
+		doer := &flakyDoer{Fails: 1}
+		c, _ := NewPetStoreClient(server.URL, WithHTTPClient(doer), WithRetry(2, time.Millisecond), WithIdempotencyKeys())
+		_, err := c.AddPet(ctx, openapi.NewPet{Name: "foo"})
+		assert.NoError(t, err)
+		assert.Equal(t, 2, doer.Calls)
+	})
+
+	// abnormal 503
+	t.Run("ABNORMAL_Retry_NotPost", func(t *testing.T) {
+		doer := &flakyDoer{Fails: 1}
+		c, _ := NewPetStoreClient(server.URL, WithHTTPClient(doer), WithRetry(2, time.Millisecond))
+		_, err := c.AddPet(ctx, openapi.NewPet{Name: "foo"})
+		assert.Error(t, err)
+		assert.Equal(t, 1, doer.Calls)
+	})
+
+	// abnormal 503
+	t.Run("ABNORMAL_Retry_Patch", func(t *testing.T) {
+		doer := &flakyDoer{Fails: 1}
+		retry := &retryDoer{Doer: doer, Retry: Retry{MaxRetries: 2, Backoff: time.Millisecond}}
+		req, _ := http.NewRequestWithContext(ctx, http.MethodPatch, server.URL+"/pets/1", strings.NewReader(`{"name":"foo"}`))
+		req.Header.Set("Idempotency-Key", "patch")
+		res, err := retry.Do(req)
+		if assert.NoError(t, err) {
+			res.Body.Close()
+			assert.Equal(t, http.StatusServiceUnavailable, res.StatusCode)
+		}
+		assert.Equal(t, 1, doer.Calls)
+	})
+}
+
+// lostResponseDoer sends requests but loses the responses of the first Losts calls.
+type lostResponseDoer struct {
+	Losts int
+	Calls int
+}
+
+func (d *lostResponseDoer) Do(req *http.Request) (*http.Response, error) {
+	d.Calls++
+	res, err := http.DefaultClient.Do(req)
+	if err != nil || d.Calls > d.Losts {
+		return res, err
+	}
+	res.Body.Close()
+	return nil, errors.New("connection reset")
+}
+
+// flakyDoer responds 503 for the first Fails calls.
+type flakyDoer struct {
+	Fails int
+	Calls int
+}
+
+func (d *flakyDoer) Do(req *http.Request) (*http.Response, error) {
+	d.Calls++
+	if d.Calls <= d.Fails {
+		return &http.Response{
+			StatusCode: http.StatusServiceUnavailable,
+			Header:     http.Header{},
+			Body:       ioutil.NopCloser(strings.NewReader("")),
+			Request:    req,
+		}, nil
+	}
+	return http.DefaultClient.Do(req)
+}
+
+// newServer serves the API on a fresh database, requests returns the received requests.
+func newServer(t *testing.T) (*httptest.Server, func() []*http.Request) {
+	db, _ := sqlx.Connect("sqlite3", ":memory:")
+	db.SetMaxOpenConns(1)
+	t.Cleanup(func() { db.Close() })
+
+	migrator, _ := migration.NewMigrator(db)
+	if _, err := migrator.Up(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	swagger, err := openapi.GetSwagger()
+	if err != nil {
+		t.Fatal(err)
+	}
+	swagger.Servers = nil
+
+	handler := delivery.NewPetStoreDelivery(
+		usecase.NewPetStoreUsecase(repository.NewUnitOfWork(db), repository.NewPetStoreRepository(db)),
+		usecase.NewIdempotencyUsecase(repository.NewIdempotencyRepository(db), time.Hour, time.Minute), delivery.Config{})
+	router := chi.NewRouter()
+	requests := []*http.Request{}
+	router.Use(func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			requests = append(requests, r)
+			next.ServeHTTP(w, r)
+		})
+	})
+	router.Use(delivery.ResponseValidator(swagger, delivery.ResponseValidationFail))
+	router.Use(delivery.RequestValidator(swagger))
+	openapi.HandlerFromMux(handler, router)
+
+	return httptest.NewServer(router), func() []*http.Request { return requests }
+}
+
+func strp(s string) *string {
+	return &s
+}
@@ -0,0 +1,95 @@
+package client
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/opbls/scapo/petstore/openapi"
+)
+
+// Retry retries idempotent requests, GET, HEAD, PUT, DELETE and OPTIONS, on network errors, 429, 502, 503 and 504.
+// Backoff doubles on each retry, Retry-After of the response is honored if longer.
+// POST is retried only with Idempotency-Key, otherwise it may be applied twice. PATCH is never retried.
+// With Idempotency-Key, 409 of the request in progress is retried as well.
+type Retry struct {
+	MaxRetries int
+	Backoff    time.Duration
+}
+
+// retryDoer is openapi.HttpRequestDoer retrying by Retry.
+type retryDoer struct {
+	Doer  openapi.HttpRequestDoer
+	Retry Retry
+}
+
+// Do Impl.
+func (d *retryDoer) Do(req *http.Request) (*http.Response, error) {
+	backoff := d.Retry.Backoff
+	for attempt := 0; ; attempt++ {
+		res, err := d.Doer.Do(req)
+		if attempt >= d.Retry.MaxRetries || !retryable(req, res, err) {
+			return res, err
+		}
+
+		wait := backoff
+		if res != nil {
+			if after := retryAfter(res); after > wait {
+				wait = after
+			}
+			res.Body.Close()
+		}
+		select {
+		case <-req.Context().Done():
+			return nil, req.Context().Err()
+		case <-time.After(wait):
+		}
+		backoff *= 2
+
+		if req.GetBody != nil {
+			body, err := req.GetBody()
+			if err != nil {
+				return nil, err
+			}
+			req.Body = body
+		}
+	}
+}
+
+// idempotentMethods are retried without Idempotency-Key.
+var idempotentMethods = map[string]bool{
+	http.MethodGet: true, http.MethodHead: true, http.MethodPut: true, http.MethodDelete: true, http.MethodOptions: true,
+}
+
+func retryable(req *http.Request, res *http.Response, err error) bool {
+	idempotencyKey := req.Header.Get("Idempotency-Key") != ""
+	if !idempotentMethods[req.Method] && !(req.Method == http.MethodPost && idempotencyKey) {
+		return false
+	}
+	if req.Context().Err() != nil {
+		return false
+	}
+	if req.Body != nil && req.Body != http.NoBody && req.GetBody == nil {
+		// body can not be sent again
+		return false
+	}
+	if err != nil {
+		return true
+	}
+	switch res.StatusCode {
+	case http.StatusTooManyRequests, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	case http.StatusConflict:
+		return idempotencyKey
+	}
+	return false
+}
+
+// retryAfter returns Retry-After in seconds, 0 if not set.
+func retryAfter(res *http.Response) time.Duration {
+	s, err := strconv.Atoi(res.Header.Get("Retry-After"))
+	if err != nil || s < 0 {
+		return 0
+	}
+	return time.Duration(s) * time.Second
+}
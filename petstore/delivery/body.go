@@ -0,0 +1,113 @@
+package delivery
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strings"
+
+	"github.com/opbls/scapo/petstore/domain"
+)
+
+// LimitBody responds 413 to request bodies larger than n bytes, no limit if n is not positive.
+// The body is read before the request validator, so it never sees a truncated body.
+func LimitBody(n int64) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		if n <= 0 {
+			return next
+		}
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.ContentLength > n {
+				writeError(w, r, bodyTooLarge(n))
+				return
+			}
+			if r.Body == nil || r.Body == http.NoBody {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			b, err := ioutil.ReadAll(io.LimitReader(r.Body, n+1))
+			r.Body.Close()
+			if err != nil {
+				writeError(w, r, domain.Err400BadRequest.Wrap(err))
+				return
+			}
+			if int64(len(b)) > n {
+				writeError(w, r, bodyTooLarge(n))
+				return
+			}
+			r.Body = ioutil.NopCloser(bytes.NewReader(b))
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+func bodyTooLarge(n int64) error {
+	return domain.Err413PayloadTooLarge.WithMessage(fmt.Sprintf("Request Body Larger Than %d Bytes", n))
+}
+
+// decodeBody decodes JSON body of r to v.
+// Malformed JSON, wrong types and unknown fields if disallowUnknown are Err400BadRequest with violations.
+func decodeBody(r *http.Request, v interface{}, disallowUnknown bool) error {
+	dec := json.NewDecoder(r.Body)
+	if disallowUnknown {
+		dec.DisallowUnknownFields()
+	}
+
+	err := dec.Decode(v)
+	if err == nil && dec.More() {
+		err = errors.New("unexpected data after the JSON value")
+	}
+	if err == nil {
+		return nil
+	}
+
+	violation := domain.Violation{Field: "body", Code: "malformed", Message: err.Error()}
+	syntaxErr := &json.SyntaxError{}
+	typeErr := &json.UnmarshalTypeError{}
+	switch {
+	case errors.Is(err, io.EOF):
+		violation.Code = "required"
+		violation.Message = "body is empty"
+	case errors.As(err, &syntaxErr):
+		violation.Message = fmt.Sprintf("%s at offset %d", syntaxErr, syntaxErr.Offset)
+	case errors.As(err, &typeErr):
+		violation.Field = typeErr.Field
+		violation.Code = "type"
+		violation.Message = "must be " + typeErr.Type.String()
+	case strings.HasPrefix(err.Error(), "json: unknown field "):
+		// encoding/json has no type for this error
+		violation.Field = strings.Trim(strings.TrimPrefix(err.Error(), "json: unknown field "), `"`)
+		violation.Code = "unknown"
+		violation.Message = "unknown field"
+	}
+	return domain.Err400BadRequest.Wrap(err).WithViolations(violation)
+}
+
+// decodeBodyFields decodes JSON body of r to v as decodeBody, and returns the fields present in the body.
+func decodeBodyFields(r *http.Request, v interface{}, disallowUnknown bool) ([]string, error) {
+	b, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		return nil, domain.Err400BadRequest.Wrap(err)
+	}
+	r.Body = ioutil.NopCloser(bytes.NewReader(b))
+	if err := decodeBody(r, v, disallowUnknown); err != nil {
+		return nil, err
+	}
+
+	// decoded to v, so the body is a JSON object
+	fields := map[string]json.RawMessage{}
+	if err := json.Unmarshal(b, &fields); err != nil {
+		return nil, domain.Err400BadRequest.Wrap(err)
+	}
+	rslts := []string{}
+	for field := range fields {
+		rslts = append(rslts, field)
+
+	}
+	return rslts, nil
+}
@@ -0,0 +1,109 @@
+package delivery
+
+import (
+	"embed"
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/ghodss/yaml"
+)
+
+// explorer is the API explorer, self-contained to work offline.
+//
+//go:embed docs/index.html
+var explorer embed.FS
+
+type (
+	// DocsDelivery interface.
+	DocsDelivery interface {
+		// OpenAPIJSON serves the spec in JSON.
+		OpenAPIJSON(w http.ResponseWriter, r *http.Request)
+		// OpenAPIYAML serves the spec in YAML.
+		OpenAPIYAML(w http.ResponseWriter, r *http.Request)
+		// Docs serves the API explorer of the spec.
+		Docs(w http.ResponseWriter, r *http.Request)
+	}
+
+	// DocsDeliveryImpl struct.
+	DocsDeliveryImpl struct {
+		Swagger *openapi3.Swagger
+		// BasePath is where the API is mounted, like /v1.
+		BasePath string
+		// TrustForwarded takes the base URL from X-Forwarded-Proto and X-Forwarded-Host,
+		// which any client can send unless a proxy overwrites them.
+		TrustForwarded bool
+	}
+)
+
+// NewDocsDelivery returns DocsDelivery of swagger mounted at basePath.
+func NewDocsDelivery(swagger *openapi3.Swagger, basePath string, trustForwarded bool) DocsDelivery {
+	return &DocsDeliveryImpl{
+		Swagger:        swagger,
+		BasePath:       basePath,
+		TrustForwarded: trustForwarded,
+	}
+}
+
+// OpenAPIJSON Impl.
+func (impl *DocsDeliveryImpl) OpenAPIJSON(w http.ResponseWriter, r *http.Request) {
+	b, err := impl.spec(r)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.Write(b)
+}
+
+// OpenAPIYAML Impl.
+func (impl *DocsDeliveryImpl) OpenAPIYAML(w http.ResponseWriter, r *http.Request) {
+	b, err := impl.spec(r)
+	if err == nil {
+		b, err = yaml.JSONToYAML(b)
+	}
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/yaml")
+	w.Write(b)
+}
+
+// Docs Impl.
+func (impl *DocsDeliveryImpl) Docs(w http.ResponseWriter, r *http.Request) {
+	b, err := explorer.ReadFile("docs/index.html")
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.Write(b)
+}
+
+// spec returns JSON of the spec with servers rewritten to the base URL of r and BasePath.
+func (impl *DocsDeliveryImpl) spec(r *http.Request) ([]byte, error) {
+	swagger := *impl.Swagger
+	swagger.Servers = openapi3.Servers{{URL: baseURL(r, impl.TrustForwarded) + impl.BasePath}}
+	return json.Marshal(&swagger)
+}
+
+// baseURL returns scheme and host the client requested, behind a proxy as well if trustForwarded.
+func baseURL(r *http.Request, trustForwarded bool) string {
+	scheme := "http"
+	if r.TLS != nil {
+		scheme = "https"
+	}
+	if !trustForwarded {
+		return scheme + "://" + r.Host
+	}
+	if proto := r.Header.Get("X-Forwarded-Proto"); proto != "" {
+		scheme = strings.TrimSpace(strings.Split(proto, ",")[0])
+	}
+	host := r.Host
+	if fwd := r.Header.Get("X-Forwarded-Host"); fwd != "" {
+		host = strings.TrimSpace(strings.Split(fwd, ",")[0])
+	}
+	return scheme + "://" + host
+}
@@ -0,0 +1,89 @@
+package delivery
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/opbls/scapo/petstore/domain"
+)
+
+// petETag returns strong ETag of Pet of version.
+func petETag(version int64) string {
+	return `"` + strconv.FormatInt(version, 10) + `"`
+}
+
+// bodyETag returns strong ETag of response body b.
+func bodyETag(b []byte) string {
+	sum := sha256.Sum256(b)
+	return `"` + hex.EncodeToString(sum[:16]) + `"`
+}
+
+// ifMatchVersion returns the version required by If-Match, 0 if any version.
+// ETags not issued by petETag never match.
+func ifMatchVersion(r *http.Request) (int64, error) {
+	tags := splitETags(r.Header.Get("If-Match"))
+	switch {
+	case len(tags) == 0:
+		return 0, nil
+	case len(tags) > 1:
+		return 0, domain.Err400BadRequest.WithViolations(domain.Violation{
+			Field: "If-Match", Code: "multiple", Message: "must be one entity tag"})
+	case tags[0] == "*":
+		return 0, nil
+	}
+
+	// strong comparison
+	version, err := strconv.ParseInt(strings.Trim(tags[0], `"`), 10, 64)
+	if err != nil || version <= 0 || strings.HasPrefix(tags[0], "W/") {
+		return -1, nil
+	}
+	return version, nil
+}
+
+// noneMatch reports If-None-Match of r does not match etag, weak comparison as RFC 7232.
+func noneMatch(r *http.Request, etag string) bool {
+	for _, tag := range splitETags(r.Header.Get("If-None-Match")) {
+		if tag == "*" || strings.TrimPrefix(tag, "W/") == etag {
+			return false
+		}
+	}
+	return true
+}
+
+func splitETags(header string) []string {
+	rslts := []string{}
+	for _, tag := range strings.Split(header, ",") {
+		if tag = strings.TrimSpace(tag); tag != "" {
+			rslts = append(rslts, tag)
+		}
+	}
+	return rslts
+}
+
+// write200OKWithETag writes objects with ETag, etag of the body if empty.
+// GET matching If-None-Match is 304 without body.
+func write200OKWithETag(w http.ResponseWriter, r *http.Request, etag string, objects interface{}) {
+	b, err := json.Marshal(objects)
+	if err != nil {
+		writeError(w, r, domain.Err500InternalServerError.Wrap(err))
+		return
+	}
+	// same as json.Encoder
+	b = append(b, '\n')
+	if etag == "" {
+		etag = bodyETag(b)
+	}
+
+	w.Header().Set("ETag", etag)
+	if r.Method == http.MethodGet && !noneMatch(r, etag) {
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	w.Write(b)
+}
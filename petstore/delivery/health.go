@@ -0,0 +1,62 @@
+package delivery
+
+import (
+	"net/http"
+
+	"github.com/opbls/scapo/petstore/usecase"
+)
+
+type (
+	// HealthDelivery interface.
+	HealthDelivery interface {
+		// Healthz reports the process is alive.
+		Healthz(w http.ResponseWriter, r *http.Request)
+		// Readyz reports the store is available.
+		Readyz(w http.ResponseWriter, r *http.Request)
+		// RequireReady responds 503 while the store is unavailable.
+		RequireReady(next http.Handler) http.Handler
+	}
+
+	// HealthDeliveryImpl struct.
+	HealthDeliveryImpl struct {
+		Usecase usecase.HealthUsecase
+	}
+
+	// healthStatus is the body of health responses.
+	healthStatus struct {
+		Status string `json:"status"`
+	}
+)
+
+// NewHealthDelivery returns HealthDelivery.
+func NewHealthDelivery(usecase usecase.HealthUsecase) HealthDelivery {
+	return &HealthDeliveryImpl{
+		Usecase: usecase,
+	}
+}
+
+// Healthz Impl.
+func (impl *HealthDeliveryImpl) Healthz(w http.ResponseWriter, r *http.Request) {
+	write200OK(w, healthStatus{Status: "ok"})
+}
+
+// Readyz Impl.
+func (impl *HealthDeliveryImpl) Readyz(w http.ResponseWriter, r *http.Request) {
+	if err := impl.Usecase.Ready(r.Context()); err != nil {
+		writeError(w, r, err)
+		return
+	}
+	write200OK(w, healthStatus{Status: "ready"})
+}
+
+// RequireReady Impl.
+func (impl *HealthDeliveryImpl) RequireReady(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := impl.Usecase.Ready(r.Context()); err != nil {
+			w.Header().Set("Retry-After", "1")
+			writeError(w, r, err)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
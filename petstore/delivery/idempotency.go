@@ -0,0 +1,99 @@
+package delivery
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"net/http"
+
+	"github.com/opbls/scapo/logger"
+	"github.com/opbls/scapo/petstore/domain"
+)
+
+// replayedHeaders are response headers stored to replay.
+var replayedHeaders = []string{"Content-Type", "Location"}
+
+// idempotent serves r by serve once for each Idempotency-Key, without key r is just served.
+// Responses except 5xx are stored and replayed to retries with Idempotent-Replayed: true,
+// 5xx releases the key so that the request can be retried.
+func (impl *PetStoreDeliveryImpl) idempotent(w http.ResponseWriter, r *http.Request, key *string, serve func(w http.ResponseWriter)) {
+	if key == nil || impl.Idempotency == nil {
+		serve(w)
+		return
+	}
+
+	body, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		writeError(w, r, domain.Err400BadRequest.Wrap(err))
+		return
+	}
+	r.Body = ioutil.NopCloser(bytes.NewReader(body))
+
+	scope := r.Method + " " + r.URL.Path
+	existing, err := impl.Idempotency.Begin(r.Context(), scope, *key, hashRequest(scope, body))
+	if err != nil {
+		if errors.Is(err, domain.Err409Conflict) {
+			w.Header().Set("Retry-After", "1")
+		}
+		writeError(w, r, err)
+		return
+	}
+	if existing != nil {
+		for k, v := range existing.Header {
+			w.Header().Set(k, v)
+		}
+		w.Header().Set("Idempotent-Replayed", "true")
+		w.WriteHeader(*existing.Status)
+		w.Write(existing.Body)
+		return
+	}
+
+	buf := newResponseBuffer()
+	serve(buf)
+
+	// the response is stored even if the request is canceled after served
+	ctx := context.Background()
+	if buf.status >= http.StatusInternalServerError {
+		if err := impl.Idempotency.Abort(ctx, scope, *key); err != nil {
+			logger.Error(err)
+		}
+	} else {
+		rec := &domain.IdempotencyRecord{
+			Scope:  scope,
+			Key:    *key,
+			Status: &buf.status,
+			Header: map[string]string{},
+			Body:   buf.body.Bytes(),
+		}
+		for _, k := range replayedHeaders {
+			if v := buf.header.Get(k); v != "" {
+				rec.Header[k] = v
+			}
+		}
+		if err := impl.Idempotency.Complete(ctx, rec); err != nil {
+			logger.Error(err)
+		}
+	}
+	buf.flush(w)
+}
+
+// hashRequest returns hash of the request in scope.
+// JSON body is canonicalized, so that the order of fields and spaces do not matter.
+func hashRequest(scope string, body []byte) string {
+	var v interface{}
+	if err := json.Unmarshal(body, &v); err == nil {
+		if b, err := json.Marshal(v); err == nil {
+			body = b
+		}
+	}
+
+	h := sha256.New()
+	h.Write([]byte(scope))
+	h.Write([]byte{0})
+	h.Write(body)
+	return hex.EncodeToString(h.Sum(nil))
+}
@@ -0,0 +1,220 @@
+package delivery
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/getkin/kin-openapi/openapi3filter"
+	"github.com/opbls/scapo/logger"
+	"github.com/opbls/scapo/petstore/domain"
+)
+
+// ResponseValidation is how ResponseValidator treats mismatches.
+type ResponseValidation string
+
+const (
+	// ResponseValidationOff does not check responses.
+	ResponseValidationOff ResponseValidation = "off"
+	// ResponseValidationLog logs mismatches and sends responses as is.
+	ResponseValidationLog ResponseValidation = "log"
+	// ResponseValidationFail logs mismatches and replaces them with 500.
+	ResponseValidationFail ResponseValidation = "fail"
+)
+
+// RequestValidator validates requests by swagger spec.
+// Unlike middleware.OapiRequestValidator, invalid requests are written as problem details.
+func RequestValidator(swagger *openapi3.Swagger) func(http.Handler) http.Handler {
+	router := newRouter(swagger)
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			route, pathParams, err := findRoute(router, r)
+			if err != nil {
+				writeError(w, r, domain.Err404NotFound.Wrap(err))
+				return
+			}
+
+			input := &openapi3filter.RequestValidationInput{
+				Request:    r,
+				PathParams: pathParams,
+				Route:      route,
+			}
+			if err := openapi3filter.ValidateRequest(r.Context(), input); err != nil {
+				writeError(w, r, requestValidationError(err))
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// newRouter returns router of swagger for findRoute.
+// Path variables of openapi3filter match until '/', so custom methods like /pets/{id}:restore
+// are routed as /pets/{id}/:restore. swagger is not modified.
+func newRouter(swagger *openapi3.Swagger) *openapi3filter.Router {
+	s := *swagger
+	s.Paths = openapi3.Paths{}
+	for path, item := range swagger.Paths {
+		s.Paths[customMethodPath(path)] = item
+	}
+	return openapi3filter.NewRouter().WithSwagger(&s)
+}
+
+// findRoute returns route of r by router of newRouter.
+func findRoute(router *openapi3filter.Router, r *http.Request) (*openapi3filter.Route, map[string]string, error) {
+	u := *r.URL
+	u.Path = customMethodPath(u.Path)
+	return router.FindRoute(r.Method, &u)
+}
+
+// customMethodPath separates custom method of the last segment by '/'.
+func customMethodPath(path string) string {
+	i := strings.LastIndex(path, "/")
+	if j := strings.LastIndex(path, ":"); i >= 0 && j > i {
+		return path[:j] + "/" + path[j:]
+	}
+	return path
+}
+
+// requestValidationError returns err of openapi3filter as domain.Error.
+func requestValidationError(err error) error {
+	e := &openapi3filter.RequestError{}
+	if !errors.As(err, &e) {
+		return domain.Err500InternalServerError.Wrap(err)
+	}
+
+	// openapi errors are multi-line with a decent message on the first
+	message := strings.Split(e.Error(), "\n")[0]
+	field := "body"
+	if e.Parameter != nil {
+		field = e.Parameter.Name
+	}
+	return domain.Err400BadRequest.Wrap(err).WithViolations(domain.Violation{
+		Field:   field,
+		Code:    "invalid",
+		Message: message,
+	})
+}
+
+// ResponseValidator validates responses by swagger spec.
+// Status code, Content-Type and body must be documented for the operation,
+// and 1xx to 3xx must not fall back on the default response, which is for errors.
+// Responses are buffered to be checked, so this is meant for tests and staging.
+func ResponseValidator(swagger *openapi3.Swagger, mode ResponseValidation) func(http.Handler) http.Handler {
+	router := newRouter(swagger)
+
+	return func(next http.Handler) http.Handler {
+		if mode == ResponseValidationOff || mode == "" {
+			return next
+		}
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			route, pathParams, err := findRoute(router, r)
+			if err != nil {
+				// not in the spec
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			buf := newResponseBuffer()
+			next.ServeHTTP(buf, r)
+			if buf.status == 0 {
+				buf.status = http.StatusOK
+			}
+
+			err = validateResponse(r, route, pathParams, buf)
+			if err == nil {
+				buf.flush(w)
+				return
+			}
+			if mode != ResponseValidationFail {
+				logger.Warn(err)
+				buf.flush(w)
+				return
+			}
+			writeError(w, r, domain.Err500InternalServerError.Wrap(err))
+		})
+	}
+}
+
+func validateResponse(r *http.Request, route *openapi3filter.Route, pathParams map[string]string, buf *responseBuffer) error {
+	status := buf.status
+	if status < http.StatusBadRequest && route.Operation.Responses.Get(status) == nil {
+		switch status {
+		// never validated by openapi3filter
+		case http.StatusNotModified, http.StatusPermanentRedirect, http.StatusTemporaryRedirect, http.StatusMovedPermanently:
+		default:
+			return fmt.Errorf("response of %s %s: status %d is not documented", r.Method, route.Path, status)
+		}
+	}
+
+	input := &openapi3filter.ResponseValidationInput{
+		RequestValidationInput: &openapi3filter.RequestValidationInput{
+			Request:    r,
+			PathParams: pathParams,
+			Route:      route,
+		},
+		Status: status,
+		Header: buf.header,
+		Options: &openapi3filter.Options{
+			IncludeResponseStatus: true,
+		},
+	}
+	input.SetBodyBytes(buf.body.Bytes())
+	// the request is already served, its context may be done
+	if err := openapi3filter.ValidateResponse(context.Background(), input); err != nil {
+		return fmt.Errorf("response of %s %s: %w", r.Method, route.Path, err)
+	}
+	if status == http.StatusNoContent && buf.body.Len() > 0 {
+		return fmt.Errorf("response of %s %s: status 204 has body", r.Method, route.Path)
+	}
+	return nil
+}
+
+// responseBuffer is http.ResponseWriter holding response until flush.
+type responseBuffer struct {
+	header http.Header
+	status int
+	body   bytes.Buffer
+}
+
+func newResponseBuffer() *responseBuffer {
+	return &responseBuffer{header: http.Header{}}
+}
+
+// Header Impl.
+func (b *responseBuffer) Header() http.Header {
+	return b.header
+}
+
+// WriteHeader Impl.
+func (b *responseBuffer) WriteHeader(status int) {
+	if b.status == 0 {
+		b.status = status
+	}
+}
+
+// Write Impl.
+func (b *responseBuffer) Write(p []byte) (int, error) {
+	if b.status == 0 {
+		b.WriteHeader(http.StatusOK)
+	}
+	if _, ok := b.header["Content-Type"]; !ok {
+		// same as http.ResponseWriter
+		b.header.Set("Content-Type", http.DetectContentType(p))
+	}
+	return b.body.Write(p)
+}
+
+func (b *responseBuffer) flush(w http.ResponseWriter) {
+	for k, v := range b.header {
+		w.Header()[k] = v
+	}
+	w.WriteHeader(b.status)
+	w.Write(b.body.Bytes())
+}
@@ -0,0 +1,259 @@
+package delivery
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"path"
+	"strconv"
+
+	"github.com/opbls/scapo/petstore/domain"
+	"github.com/opbls/scapo/petstore/openapiv2"
+	"github.com/opbls/scapo/petstore/usecase"
+)
+
+type (
+	// OwnerDelivery interface, operations of owners and adoptions in v2.
+	OwnerDelivery interface {
+		FindOwners(w http.ResponseWriter, r *http.Request, params openapiv2.FindOwnersParams)
+		AddOwner(w http.ResponseWriter, r *http.Request)
+		FindOwnerById(w http.ResponseWriter, r *http.Request, id int64)
+		UpdateOwner(w http.ResponseWriter, r *http.Request, id int64)
+		DeleteOwner(w http.ResponseWriter, r *http.Request, id int64)
+		FindOwnerPets(w http.ResponseWriter, r *http.Request, id int64)
+		AdoptPet(w http.ResponseWriter, r *http.Request)
+		FindAdoptionById(w http.ResponseWriter, r *http.Request, id int64)
+	}
+
+	// OwnerDeliveryImpl struct.
+	OwnerDeliveryImpl struct {
+		Usecase usecase.OwnerUsecase
+		Config  Config
+	}
+)
+
+// errInvalidOwnerCursor is returned for cursor not issued by encodeOwnerCursor.
+var errInvalidOwnerCursor = domain.Err400BadRequest.WithViolations(
+	domain.Violation{Field: "cursor", Code: "invalid", Message: "is not a cursor of owners"})
+
+// ownerCursor is the id of the last Owner of a page.
+type ownerCursor struct {
+	ID int64 `json:"o"`
+}
+
+// NewOwnerDelivery returns OwnerDelivery.
+func NewOwnerDelivery(usecase usecase.OwnerUsecase, config Config) OwnerDelivery {
+	if config.DefaultPageSize <= 0 {
+		config.DefaultPageSize = defaultPageSize
+	}
+	return &OwnerDeliveryImpl{
+		Usecase: usecase,
+		Config:  config,
+	}
+}
+
+// FindOwners Impl.
+// Link and X-Next-Cursor are set if there is the next page.
+func (impl *OwnerDeliveryImpl) FindOwners(w http.ResponseWriter, r *http.Request, params openapiv2.FindOwnersParams) {
+
+	limit := impl.Config.DefaultPageSize
+	if params.Limit != nil {
+		limit = int(*params.Limit)
+	}
+	var afterID int64
+	if params.Cursor != nil {
+		id, err := decodeOwnerCursor(*params.Cursor)
+		if err != nil {
+			writeError(w, r, err)
+			return
+		}
+		afterID = id
+	}
+
+	// one more to know whether the next page exists
+	owners, err := impl.Usecase.FindOwners(r.Context(), afterID, limit+1)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	rslt := openapiv2.OwnerList{Items: []openapiv2.Owner{}}
+	if len(*owners) > limit {
+		*owners = (*owners)[:limit]
+		if limit > 0 {
+			cursor := encodeOwnerCursor((*owners)[limit-1].Id)
+			writeNextLink(w, r, cursor)
+			rslt.NextCursor = &cursor
+		}
+	}
+	for _, o := range *owners {
+		rslt.Items = append(rslt.Items, toOwner(o))
+	}
+	// response
+	write200OK(w, rslt)
+}
+
+// AddOwner Impl.
+// Responds 201 with Location of the created Owner.
+func (impl *OwnerDeliveryImpl) AddOwner(w http.ResponseWriter, r *http.Request) {
+
+	body := openapiv2.NewOwner{}
+	if err := decodeBody(r, &body, impl.Config.DisallowUnknownFields); err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	o, err := impl.Usecase.AddOwner(r.Context(), &domain.Owner{Name: body.Name, Email: body.Email, Phone: body.Phone})
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+	// response
+	write201Created(w, path.Join(r.URL.Path, strconv.FormatInt(o.Id, 10)), toOwner(*o))
+}
+
+// FindOwnerById Impl.
+func (impl *OwnerDeliveryImpl) FindOwnerById(w http.ResponseWriter, r *http.Request, id int64) {
+
+	o, err := impl.Usecase.FindOwnerById(r.Context(), int(id))
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	if o == nil {
+		writeError(w, r, domain.Err404NotFound)
+		return
+	}
+	// response
+	write200OK(w, toOwner(*o))
+}
+
+// UpdateOwner Impl.
+func (impl *OwnerDeliveryImpl) UpdateOwner(w http.ResponseWriter, r *http.Request, id int64) {
+
+	body := openapiv2.NewOwner{}
+	if err := decodeBody(r, &body, impl.Config.DisallowUnknownFields); err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	o, err := impl.Usecase.UpdateOwner(r.Context(), int(id), &domain.Owner{Name: body.Name, Email: body.Email, Phone: body.Phone})
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	if o == nil {
+		writeError(w, r, domain.Err404NotFound)
+		return
+	}
+	// response
+	write200OK(w, toOwner(*o))
+}
+
+// DeleteOwner Impl.
+func (impl *OwnerDeliveryImpl) DeleteOwner(w http.ResponseWriter, r *http.Request, id int64) {
+
+	i, err := impl.Usecase.DeleteOwner(r.Context(), int(id))
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	//act as not found
+	if i == 0 {
+		writeError(w, r, domain.Err404NotFound)
+		return
+	}
+
+	write204NoContent(w)
+}
+
+// FindOwnerPets Impl.
+func (impl *OwnerDeliveryImpl) FindOwnerPets(w http.ResponseWriter, r *http.Request, id int64) {
+
+	pets, err := impl.Usecase.FindOwnerPets(r.Context(), int(id))
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	if pets == nil {
+		writeError(w, r, domain.Err404NotFound)
+		return
+	}
+	// response
+	rslt := openapiv2.PetList{Items: []openapiv2.Pet{}}
+	for _, p := range *pets {
+		rslt.Items = append(rslt.Items, toPetV2(domain.Pet(p)))
+	}
+	write200OK(w, rslt)
+}
+
+// AdoptPet Impl.
+// Responds 201 with Location of the created Adoption.
+func (impl *OwnerDeliveryImpl) AdoptPet(w http.ResponseWriter, r *http.Request) {
+
+	body := openapiv2.NewAdoption{}
+	if err := decodeBody(r, &body, impl.Config.DisallowUnknownFields); err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	a, err := impl.Usecase.AdoptPet(r.Context(), &domain.Adoption{PetId: body.PetId, OwnerId: body.OwnerId, Actor: body.Actor})
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+	// response
+	write201Created(w, path.Join(r.URL.Path, strconv.FormatInt(a.Id, 10)), toAdoption(*a))
+}
+
+// FindAdoptionById Impl.
+func (impl *OwnerDeliveryImpl) FindAdoptionById(w http.ResponseWriter, r *http.Request, id int64) {
+
+	a, err := impl.Usecase.FindAdoptionById(r.Context(), int(id))
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	if a == nil {
+		writeError(w, r, domain.Err404NotFound)
+		return
+	}
+	// response
+	write200OK(w, toAdoption(*a))
+}
+
+func toOwner(o domain.Owner) openapiv2.Owner {
+	rslt := openapiv2.Owner{Id: o.Id, CreatedAt: o.CreatedAt}
+	rslt.Name = o.Name
+	rslt.Email = o.Email
+	rslt.Phone = o.Phone
+	return rslt
+}
+
+func toAdoption(a domain.Adoption) openapiv2.Adoption {
+	return openapiv2.Adoption{Id: a.Id, PetId: a.PetId, OwnerId: a.OwnerId, Actor: a.Actor, CreatedAt: a.CreatedAt}
+}
+
+// encodeOwnerCursor returns opaque cursor pointing after the Owner of id.
+func encodeOwnerCursor(id int64) string {
+	b, _ := json.Marshal(ownerCursor{ID: id})
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+// decodeOwnerCursor returns id of the Owner the cursor points after.
+func decodeOwnerCursor(s string) (int64, error) {
+	b, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return 0, errInvalidOwnerCursor
+	}
+	c := ownerCursor{}
+	if err := json.Unmarshal(b, &c); err != nil || c.ID < 0 {
+		return 0, errInvalidOwnerCursor
+	}
+	return c.ID, nil
+}
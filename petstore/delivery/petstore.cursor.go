@@ -0,0 +1,95 @@
+package delivery
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"strings"
+
+	"github.com/opbls/scapo/petstore/domain"
+)
+
+// errInvalidCursor is returned for cursor not issued by encodeCursor for the sort.
+var errInvalidCursor = domain.Err400BadRequest.WithViolations(
+	domain.Violation{Field: "cursor", Code: "invalid", Message: "is not a cursor of this sort"})
+
+// cursor is the keyset of the last Pet of a page.
+type cursor struct {
+	Sort string `json:"s"`
+	ID   int64  `json:"i"`
+	Name string `json:"n,omitempty"`
+}
+
+// encodeCursor returns opaque cursor pointing after the Pet in the sort order.
+func encodeCursor(sort []domain.SortKey, p domain.Pet) string {
+	c := cursor{Sort: sortString(sort), ID: p.Id}
+	for _, k := range sort {
+		if k.Field == domain.SortByName {
+			c.Name = p.Name
+		}
+	}
+	b, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+// decodeCursor returns keyset the cursor points after.
+// The cursor must be issued for the same sort.
+func decodeCursor(s string, sort []domain.SortKey) (domain.PetKeyset, error) {
+	b, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return domain.PetKeyset{}, errInvalidCursor
+	}
+	c := cursor{}
+	if err := json.Unmarshal(b, &c); err != nil {
+		return domain.PetKeyset{}, errInvalidCursor
+	}
+	if c.Sort != sortString(sort) {
+		return domain.PetKeyset{}, errInvalidCursor
+	}
+	return domain.PetKeyset{ID: c.ID, Name: c.Name}, nil
+}
+
+func sortString(sort []domain.SortKey) string {
+	keys := []string{}
+	for _, k := range sort {
+		if k.Desc {
+			keys = append(keys, "-"+string(k.Field))
+		} else {
+			keys = append(keys, string(k.Field))
+		}
+	}
+	return strings.Join(keys, ",")
+}
+
+// errInvalidSearchCursor is returned for cursor not issued by encodeSearchCursor for the query.
+var errInvalidSearchCursor = domain.Err400BadRequest.WithViolations(
+	domain.Violation{Field: "cursor", Code: "invalid", Message: "is not a cursor of this search"})
+
+// searchCursor is the offset of the next page of search results.
+// Ranks change as Pets are written, so pages are numbered rather than keyed.
+type searchCursor struct {
+	Query  string `json:"q"`
+	Offset int    `json:"o"`
+}
+
+// encodeSearchCursor returns opaque cursor of the page at offset.
+func encodeSearchCursor(query string, offset int) string {
+	b, _ := json.Marshal(searchCursor{Query: query, Offset: offset})
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+// decodeSearchCursor returns offset the cursor points to.
+// The cursor must be issued for the same query.
+func decodeSearchCursor(s string, query string) (int, error) {
+	b, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return 0, errInvalidSearchCursor
+	}
+	c := searchCursor{}
+	if err := json.Unmarshal(b, &c); err != nil {
+		return 0, errInvalidSearchCursor
+	}
+	if c.Query != query || c.Offset < 0 {
+		return 0, errInvalidSearchCursor
+	}
+	return c.Offset, nil
+}
@@ -2,11 +2,15 @@ package delivery
 
 import (
 	"encoding/json"
-	"log"
+	"errors"
+	"fmt"
 	"net/http"
+	"net/url"
+	"strings"
 
 	// sqlite driver
 	_ "github.com/mattn/go-sqlite3"
+	"github.com/opbls/scapo/logger"
 	"github.com/opbls/scapo/petstore/domain"
 	"github.com/opbls/scapo/petstore/openapi"
 	"github.com/opbls/scapo/petstore/usecase"
@@ -19,13 +23,34 @@ type (
 	// PetStoreDeliveryImpl struct.
 	PetStoreDeliveryImpl struct {
 		Usecase usecase.PetStoreUsecase
+		// Idempotency serves AddPet once for each Idempotency-Key, the key is ignored if nil.
+		Idempotency usecase.IdempotencyUsecase
+		Config      Config
+	}
+
+	// Config of PetStoreDelivery. Zero fields take the default.
+	Config struct {
+		// DefaultPageSize is the limit of FindPets when not requested.
+		DefaultPageSize int
+		// DisallowUnknownFields rejects request bodies with fields not in the spec.
+		DisallowUnknownFields bool
+		// WriteV2Fields writes all fields of Pets, otherwise only id, name and tag are written as v1.
+		WriteV2Fields bool
 	}
 )
 
+// defaultPageSize is used when Config.DefaultPageSize is not set.
+const defaultPageSize = 100
+
 // NewPetStoreDelivery returns Petstore ServerInterface.
-func NewPetStoreDelivery(usecase usecase.PetStoreUsecase) PetStoreDelivery {
+func NewPetStoreDelivery(usecase usecase.PetStoreUsecase, idempotency usecase.IdempotencyUsecase, config Config) PetStoreDelivery {
+	if config.DefaultPageSize <= 0 {
+		config.DefaultPageSize = defaultPageSize
+	}
 	return &PetStoreDeliveryImpl{
-		Usecase: usecase,
+		Usecase:     usecase,
+		Idempotency: idempotency,
+		Config:      config,
 	}
 }
 
@@ -36,71 +61,101 @@ func NewPetStoreDelivery(usecase usecase.PetStoreUsecase) PetStoreDelivery {
 // and default behavior of reserved chars are percent-encoded
 //  - allowReserved: false
 func (impl *PetStoreDeliveryImpl) FindPets(w http.ResponseWriter, r *http.Request, params openapi.FindPetsParams) {
+	pets, _, err := impl.findPets(w, r, params)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+	for i := range *pets {
+		impl.hideV2Fields((*domain.Pet)(&(*pets)[i]))
+	}
+
+	write200OKWithETag(w, r, "", pets)
+}
+
+// findPets returns a page of pets and cursor of the next page, empty on the last page.
+// Link and X-Next-Cursor are set to w if there is the next page.
+func (impl *PetStoreDeliveryImpl) findPets(w http.ResponseWriter, r *http.Request, params openapi.FindPetsParams) (*domain.Pets, string, error) {
 
 	// validate
 	if err := validatePathParam(params); err != nil {
-		writeError(w, err)
-		return
+		return nil, "", err
 	}
 
-	condition := domain.QueryCondition{}
-	if params.Tags != nil && len(*params.Tags) > 0 {
-		condition["tags"] = *params.Tags
+	limit := impl.Config.DefaultPageSize
+	if params.Limit != nil {
+		limit = int(*params.Limit)
 	}
-	if params.Limit == nil {
-		condition["limit"] = 100
-	} else {
-		condition["limit"] = params.Limit
+
+	query, err := buildPetQuery(params, limit)
+	if err != nil {
+		return nil, "", err
 	}
 
-	pets, err := impl.Usecase.FindPets(&condition)
+	pets, err := impl.Usecase.FindPets(r.Context(), query)
 	if err != nil {
-		writeError(w, err)
-		return
+		return nil, "", err
+	}
+
+	cursor := ""
+	if len(*pets) > limit {
+		*pets = (*pets)[:limit]
+		if limit > 0 {
+			cursor = encodeCursor(query.Sort, domain.Pet((*pets)[limit-1]))
+			writeNextLink(w, r, cursor)
+		}
 	}
 
-	write200OK(w, pets)
+	return pets, cursor, nil
 }
 
 // AddPet Impl.
-func (impl *PetStoreDeliveryImpl) AddPet(w http.ResponseWriter, r *http.Request) {
-
-	np := domain.Pet{}
-	if err := json.NewDecoder(r.Body).Decode(&np); err != nil {
-		writeError(w, err)
-		return
-	}
+func (impl *PetStoreDeliveryImpl) AddPet(w http.ResponseWriter, r *http.Request, params openapi.AddPetParams) {
+	impl.idempotent(w, r, params.IdempotencyKey, func(w http.ResponseWriter) {
+		p, err := impl.addPet(r)
+		if err != nil {
+			writeError(w, r, err)
+			return
+		}
+		impl.hideV2Fields(p)
+
+		write200OK(w, p)
+	})
+}
 
-	// validate
-	if err := validatePet(np); err != nil {
-		writeError(w, err)
-		return
-	}
+// addPet returns the created Pet of the request body.
+func (impl *PetStoreDeliveryImpl) addPet(r *http.Request) (*domain.Pet, error) {
 
-	p, err := impl.Usecase.AddPet(&np)
-	if err != nil {
-		writeError(w, err)
-		return
+	np := domain.Pet{}
+	if err := decodeBody(r, &np, impl.Config.DisallowUnknownFields); err != nil {
+		return nil, err
 	}
 
-	write200OK(w, p)
+	return impl.Usecase.AddPet(r.Context(), &np)
 }
 
 // DeletePet Impl
+// If-Match deletes only the Pet of the ETag.
 func (impl *PetStoreDeliveryImpl) DeletePet(w http.ResponseWriter, r *http.Request, id int64) {
 
 	did := int(id)
 
-	i, err := impl.Usecase.DeletePet(did)
+	version, err := ifMatchVersion(r)
 	if err != nil {
-		writeError(w, err)
+		writeError(w, r, err)
 		return
 	}
-	log.Println("delete success affected row: ", i)
+
+	i, err := impl.Usecase.DeletePet(r.Context(), did, version)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+	logger.Debug("delete success affected row: ", i)
 
 	//act as not found
 	if i == 0 {
-		writeError(w, domain.Err404NotFound)
+		writeError(w, r, domain.Err404NotFound)
 		return
 	}
 
@@ -109,30 +164,475 @@ func (impl *PetStoreDeliveryImpl) DeletePet(w http.ResponseWriter, r *http.Reque
 }
 
 // FindPetById Impl.
+// ETag is the version of the Pet, If-None-Match of the version is 304.
 func (impl *PetStoreDeliveryImpl) FindPetById(w http.ResponseWriter, r *http.Request, id int64) {
 
 	fid := int(id)
 
-	rslt, err := impl.Usecase.FindPetById(fid)
+	rslt, err := impl.Usecase.FindPetById(r.Context(), fid)
 	if err != nil {
-		writeError(w, err)
+		writeError(w, r, err)
 		return
 	}
 
 	if rslt == nil {
 		// write204NoContent(w)
-		writeError(w, domain.Err404NotFound)
+		writeError(w, r, domain.Err404NotFound)
+		return
+	}
+	// response
+	impl.hideV2Fields(&rslt.Pet)
+	write200OKWithETag(w, r, petETag(rslt.Version), rslt)
+}
+
+// UpdatePet Impl.
+// If-Match updates only the Pet of the ETag. v1 keeps fields added in v2 absent from the body, as PATCH does.
+func (impl *PetStoreDeliveryImpl) UpdatePet(w http.ResponseWriter, r *http.Request, id int64) {
+
+	uid := int(id)
+
+	version, err := ifMatchVersion(r)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	np := domain.Pet{}
+	fields, err := decodeBodyFields(r, &np, impl.Config.DisallowUnknownFields)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	var rslt *domain.VersionedPet
+	if impl.Config.WriteV2Fields {
+		rslt, err = impl.Usecase.UpdatePet(r.Context(), uid, &np, version)
+	} else {
+		rslt, err = impl.Usecase.UpdatePetV1(r.Context(), uid, &np, version, fields)
+	}
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	if rslt == nil {
+		writeError(w, r, domain.Err404NotFound)
+		return
+	}
+	// response
+	impl.hideV2Fields(&rslt.Pet)
+	write200OKWithETag(w, r, petETag(rslt.Version), rslt)
+}
+
+// PatchPet Impl.
+// Request body is JSON Merge Patch (RFC 7386), null removes the field.
+// If-Match patches only the Pet of the ETag.
+func (impl *PetStoreDeliveryImpl) PatchPet(w http.ResponseWriter, r *http.Request, id int64) {
+
+	pid := int(id)
+
+	version, err := ifMatchVersion(r)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	patch := domain.PetPatch{}
+	if err := decodeBody(r, &patch, false); err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	// validate
+	if err := validatePetPatch(patch, impl.Config.DisallowUnknownFields); err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	rslt, err := impl.Usecase.PatchPet(r.Context(), pid, patch, version)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	if rslt == nil {
+		writeError(w, r, domain.Err404NotFound)
+		return
+	}
+	// response
+	impl.hideV2Fields(&rslt.Pet)
+	write200OKWithETag(w, r, petETag(rslt.Version), rslt)
+}
+
+// RestorePet Impl.
+func (impl *PetStoreDeliveryImpl) RestorePet(w http.ResponseWriter, r *http.Request, id int64) {
+
+	rslt, err := impl.Usecase.RestorePet(r.Context(), int(id))
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	if rslt == nil {
+		writeError(w, r, domain.Err404NotFound)
+		return
+	}
+	// response
+	impl.hideV2Fields(&rslt.Pet)
+	write200OKWithETag(w, r, petETag(rslt.Version), rslt)
+}
+
+// TransitionPet Impl.
+// If-Match transitions only the Pet of the ETag.
+func (impl *PetStoreDeliveryImpl) TransitionPet(w http.ResponseWriter, r *http.Request, id int64) {
+
+	version, err := ifMatchVersion(r)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	body := openapi.PetTransitionRequest{}
+	if err := decodeBody(r, &body, impl.Config.DisallowUnknownFields); err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	rslt, err := impl.Usecase.TransitionPet(r.Context(), int(id), body.Transition, body.Actor, version)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	if rslt == nil {
+		writeError(w, r, domain.Err404NotFound)
+		return
+	}
+	// response
+	impl.hideV2Fields(&rslt.Pet)
+	write200OKWithETag(w, r, petETag(rslt.Version), rslt)
+}
+
+// FindPetTransitions Impl.
+func (impl *PetStoreDeliveryImpl) FindPetTransitions(w http.ResponseWriter, r *http.Request, id int64) {
+
+	transitions, err := impl.Usecase.FindPetTransitions(r.Context(), int(id))
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	if transitions == nil {
+		writeError(w, r, domain.Err404NotFound)
+		return
+	}
+	// response
+	write200OK(w, openapi.PetTransitionList{Items: []openapi.PetTransition(*transitions)})
+}
+
+// AddPets Impl.
+func (impl *PetStoreDeliveryImpl) AddPets(w http.ResponseWriter, r *http.Request, params openapi.AddPetsParams) {
+
+	mode, err := parseBatchMode(params.Mode)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	nps := []domain.Pet{}
+	if err := decodeBody(r, &nps, impl.Config.DisallowUnknownFields); err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	rslt, err := impl.Usecase.AddPets(r.Context(), nps, mode)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+	for _, item := range rslt.Items {
+		if item.Pet != nil {
+			impl.hideV2Fields(item.Pet)
+		}
+	}
+	// response
+	write200OK(w, toBatchResult(r, rslt, http.StatusCreated))
+}
+
+// DeletePets Impl.
+func (impl *PetStoreDeliveryImpl) DeletePets(w http.ResponseWriter, r *http.Request, params openapi.DeletePetsParams) {
+
+	mode, err := parseBatchMode(params.Mode)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	ids := []int{}
+	for _, id := range params.Ids {
+		ids = append(ids, int(id))
+	}
+
+	rslt, err := impl.Usecase.DeletePets(r.Context(), ids, mode)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+	// response
+	write200OK(w, toBatchResult(r, rslt, http.StatusNoContent))
+}
+
+// SearchPets Impl.
+func (impl *PetStoreDeliveryImpl) SearchPets(w http.ResponseWriter, r *http.Request, params openapi.SearchPetsParams) {
+	hits, cursor, err := impl.searchPets(w, r, params)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	rslt := openapi.PetSearchResult{Items: []openapi.PetSearchHit{}}
+	for _, hit := range *hits {
+		impl.hideV2Fields(&hit.Pet)
+		rslt.Items = append(rslt.Items, openapi.PetSearchHit{Pet: openapi.Pet(hit.Pet), Highlight: hit.Highlight})
+	}
+	if cursor != "" {
+		rslt.NextCursor = &cursor
+	}
+	// response
+	write200OK(w, rslt)
+}
+
+// searchPets returns a page of search results and cursor of the next page, empty on the last page.
+// Link and X-Next-Cursor are set to w if there is the next page.
+func (impl *PetStoreDeliveryImpl) searchPets(w http.ResponseWriter, r *http.Request, params openapi.SearchPetsParams) (*domain.PetSearchHits, string, error) {
+
+	// validate
+	if err := validateSearchParam(params); err != nil {
+		return nil, "", err
+	}
+
+	limit := impl.Config.DefaultPageSize
+	if params.Limit != nil {
+		limit = int(*params.Limit)
+	}
+	// one more than limit tells whether the next page exists
+	search := domain.PetSearch{Query: params.Q, Limit: limit + 1}
+	if params.Highlight != nil {
+		search.Highlight = *params.Highlight
+	}
+	if params.Cursor != nil {
+		offset, err := decodeSearchCursor(*params.Cursor, params.Q)
+		if err != nil {
+			return nil, "", err
+		}
+		search.Offset = offset
+	}
+
+	hits, err := impl.Usecase.SearchPets(r.Context(), &search)
+	if err != nil {
+		return nil, "", err
+	}
+
+	cursor := ""
+	if len(*hits) > limit {
+		*hits = (*hits)[:limit]
+		if limit > 0 {
+			cursor = encodeSearchCursor(params.Q, search.Offset+limit)
+			writeNextLink(w, r, cursor)
+		}
+	}
+
+	return hits, cursor, nil
+}
+
+// FindTags Impl.
+func (impl *PetStoreDeliveryImpl) FindTags(w http.ResponseWriter, r *http.Request) {
+
+	tags, err := impl.Usecase.FindTags(r.Context())
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+	// response
+	write200OKWithETag(w, r, "", openapi.TagList{Items: []openapi.Tag(*tags)})
+}
+
+// RenameTag Impl.
+func (impl *PetStoreDeliveryImpl) RenameTag(w http.ResponseWriter, r *http.Request) {
+
+	body := openapi.TagRename{}
+	if err := decodeBody(r, &body, impl.Config.DisallowUnknownFields); err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	rslt, err := impl.Usecase.RenameTag(r.Context(), body.From, body.To)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	if rslt == nil {
+		writeError(w, r, domain.Err404NotFound)
+		return
+	}
+	// response
+	write200OK(w, rslt)
+}
+
+// MergeTags Impl.
+func (impl *PetStoreDeliveryImpl) MergeTags(w http.ResponseWriter, r *http.Request) {
+
+	body := openapi.TagMerge{}
+	if err := decodeBody(r, &body, impl.Config.DisallowUnknownFields); err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	rslt, err := impl.Usecase.MergeTags(r.Context(), body.From, body.Into)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	if rslt == nil {
+		writeError(w, r, domain.Err404NotFound)
 		return
 	}
 	// response
-	write200OK(w, &rslt)
+	write200OK(w, rslt)
+}
+
+// hideV2Fields removes fields of pets added in v2 unless Config.WriteV2Fields, v1 clients know only tag.
+func (impl *PetStoreDeliveryImpl) hideV2Fields(p *domain.Pet) {
+	if !impl.Config.WriteV2Fields {
+		p.Tags = nil
+		p.Species, p.Breed, p.BirthDate, p.Sex, p.Status, p.Attributes = nil, nil, nil, nil, nil, nil
+	}
+}
+
+func parseBatchMode(mode *string) (domain.BatchMode, error) {
+	if mode == nil {
+		return domain.ParseBatchMode("")
+	}
+	return domain.ParseBatchMode(*mode)
+}
+
+// toBatchResult returns BatchResult with status of applied items.
+func toBatchResult(r *http.Request, rslt *domain.BatchResult, status int) openapi.BatchResult {
+	rslts := openapi.BatchResult{Committed: rslt.Committed, Results: []openapi.BatchItemResult{}}
+	for i, item := range rslt.Items {
+		ir := openapi.BatchItemResult{Index: i, Status: status}
+		if item.ID != 0 {
+			id := item.ID
+			ir.Id = &id
+		}
+		if item.Pet != nil {
+			p := openapi.Pet(*item.Pet)
+			ir.Pet = &p
+		}
+		if item.Err != nil {
+			e := asDomainError(item.Err)
+			problem := toProblem(r, e)
+			ir.Status = e.Status
+			ir.Error = &problem
+		}
+		rslts.Results = append(rslts.Results, ir)
+	}
+	return rslts
+}
+
+// buildPetQuery returns PetQuery of FindPets parameters.
+// It queries one more Pet than limit, which tells whether the next page exists.
+func buildPetQuery(params openapi.FindPetsParams, limit int) (*domain.PetQuery, error) {
+	b := domain.NewPetQueryBuilder().Limit(limit + 1)
+	if params.Sort != nil && *params.Sort != "" {
+		for _, key := range strings.Split(*params.Sort, ",") {
+			field, err := domain.ParseSortField(strings.TrimPrefix(key, "-"))
+			if err != nil {
+				return nil, domain.Err400BadRequest.WithViolations(
+					domain.Violation{Field: "sort", Code: "invalid", Message: "can not sort by " + key})
+			}
+			b.SortBy(field, strings.HasPrefix(key, "-"))
+		}
+	}
+	if params.Tags != nil && len(*params.Tags) > 0 {
+		b.Tags(*params.Tags...)
+	}
+	if params.TagMatch != nil {
+		m, err := domain.ParseTagMatch(*params.TagMatch)
+		if err != nil {
+			return nil, err
+		}
+		b.MatchTags(m)
+	}
+	if params.NamePrefix != nil {
+		b.NamePrefix(*params.NamePrefix)
+	}
+	if params.NameContains != nil {
+		b.NameContains(*params.NameContains)
+	}
+	if params.IdGt != nil {
+		b.IDGreaterThan(*params.IdGt)
+	}
+	if params.IdLt != nil {
+		b.IDLessThan(*params.IdLt)
+	}
+	if params.HasTag != nil {
+		b.HasTag(*params.HasTag)
+	}
+	if params.Species != nil {
+		b.Species(*params.Species)
+	}
+	if params.Breed != nil {
+		b.Breed(*params.Breed)
+	}
+	if params.Sex != nil {
+		b.Sex(*params.Sex)
+	}
+	if params.Status != nil && len(*params.Status) > 0 {
+		b.Statuses(*params.Status...)
+	}
+	// the generated binder sets absent dates to zero
+	if params.BornAfter != nil && !params.BornAfter.IsZero() {
+		b.BornAfter(params.BornAfter.Time)
+	}
+	if params.BornBefore != nil && !params.BornBefore.IsZero() {
+		b.BornBefore(params.BornBefore.Time)
+	}
+	if params.IncludeDeleted != nil && *params.IncludeDeleted {
+		b.IncludeDeleted()
+	}
+
+	query, err := b.Build()
+	if err != nil {
+		return nil, err
+	}
+	if params.Cursor != nil {
+		after, err := decodeCursor(*params.Cursor, query.Sort)
+		if err != nil {
+			return nil, err
+		}
+		query.After = &after
+	}
+	return query, nil
+}
+
+func writeNextLink(w http.ResponseWriter, r *http.Request, cursor string) {
+	q := r.URL.Query()
+	q.Set("cursor", cursor)
+	next := url.URL{Path: r.URL.Path, RawQuery: q.Encode()}
+	w.Header().Set("Link", fmt.Sprintf(`<%s>; rel="next"`, next.String()))
+	w.Header().Set("X-Next-Cursor", cursor)
 }
 
 func write200OK(w http.ResponseWriter, objects interface{}) {
 	writeSuccess(w, http.StatusOK, objects)
 }
 
-func write201(w http.ResponseWriter, objects interface{}) {
+// write201Created writes the created resource at location.
+func write201Created(w http.ResponseWriter, location string, objects interface{}) {
+	w.Header().Set("Location", location)
 	writeSuccess(w, http.StatusCreated, objects)
 }
 
@@ -141,6 +641,9 @@ func write204NoContent(w http.ResponseWriter) {
 }
 
 func writeSuccess(w http.ResponseWriter, code int, objects interface{}) {
+	if objects != nil {
+		w.Header().Set("Content-Type", "application/json")
+	}
 	w.WriteHeader(code)
 	if objects != nil {
 		writer := json.NewEncoder(w)
@@ -148,30 +651,76 @@ func writeSuccess(w http.ResponseWriter, code int, objects interface{}) {
 	}
 }
 
-func writeError(w http.ResponseWriter, err error) {
-	code := getStatusCode(err)
-	commonError := openapi.Error{
-		Code:    int32(code),
-		Message: err.Error(),
+// writeError writes err as RFC 7807 problem details.
+// The body is also an openapi.Error, and Content-Type is application/json
+// when the client accepts it but not application/problem+json.
+func writeError(w http.ResponseWriter, r *http.Request, err error) {
+	e := asDomainError(err)
+	if e.Status >= http.StatusInternalServerError {
+		logger.Error(err)
+	} else {
+		logger.Warn(err)
 	}
-	w.WriteHeader(code)
-	json.NewEncoder(w).Encode(commonError)
+
+	contentType := "application/problem+json"
+	if r != nil {
+		accept := r.Header.Get("Accept")
+		if strings.Contains(accept, "application/json") && !strings.Contains(accept, contentType) {
+			contentType = "application/json"
+		}
+	}
+	w.Header().Set("Content-Type", contentType)
+	w.WriteHeader(e.Status)
+	json.NewEncoder(w).Encode(toProblem(r, e))
 }
 
-func getStatusCode(err error) int {
-	if err == nil {
-		return http.StatusOK
+// toProblem returns e as RFC 7807 problem details of r.
+func toProblem(r *http.Request, e *domain.Error) openapi.Problem {
+	status := int32(e.Status)
+	title := http.StatusText(e.Status)
+	if title == "" {
+		title = e.Message
 	}
+	problem := openapi.Problem{
+		Error: openapi.Error{
+			Code:    status,
+			Message: e.Message,
+		},
+		Type:      strp("about:blank"),
+		Title:     &title,
+		Status:    &status,
+		Detail:    &e.Message,
+		ErrorCode: &e.Code,
+	}
+	if r != nil {
+		problem.Instance = strp(r.URL.Path)
+	}
+	if len(e.Violations) > 0 {
+		violations := []openapi.Violation{}
+		for _, v := range e.Violations {
+			violations = append(violations, openapi.Violation{
+				Field:   v.Field,
+				Code:    strp(v.Code),
+				Message: v.Message,
+			})
+		}
+		problem.Violations = &violations
+	}
+	if e.AllowedTransitions != nil {
+		problem.AllowedTransitions = &e.AllowedTransitions
+	}
+	return problem
+}
 
-	log.Println(err)
-	switch err {
-	case domain.Err500InternalServerError:
-		return http.StatusInternalServerError
-	case domain.Err400BadRequest:
-		return http.StatusBadRequest
-	case domain.Err404NotFound:
-		return http.StatusNotFound
-	default:
-		return http.StatusInternalServerError
+// asDomainError returns err as domain.Error, unknown errors are internal.
+func asDomainError(err error) *domain.Error {
+	e := &domain.Error{}
+	if errors.As(err, &e) {
+		return e
 	}
+	return domain.Err500InternalServerError.Wrap(err)
+}
+
+func strp(s string) *string {
+	return &s
 }
@@ -0,0 +1,161 @@
+package delivery
+
+import (
+	"net/http"
+	"path"
+	"strconv"
+
+	"github.com/opbls/scapo/petstore/domain"
+	"github.com/opbls/scapo/petstore/openapi"
+	"github.com/opbls/scapo/petstore/openapiv2"
+	"github.com/opbls/scapo/petstore/usecase"
+)
+
+type (
+	// PetStoreDeliveryV2 interface.
+	PetStoreDeliveryV2 openapiv2.ServerInterface
+
+	// PetStoreDeliveryV2Impl struct.
+	// Operations unchanged from v1 are served by V1, owners and adoptions new in v2 by OwnerDelivery.
+	PetStoreDeliveryV2Impl struct {
+		V1 *PetStoreDeliveryImpl
+		OwnerDelivery
+	}
+)
+
+// NewPetStoreDeliveryV2 returns Petstore v2 ServerInterface.
+// Unlike v1, unknown fields of request bodies are rejected and Pets have all fields.
+func NewPetStoreDeliveryV2(usecase usecase.PetStoreUsecase, owners usecase.OwnerUsecase, idempotency usecase.IdempotencyUsecase, config Config) PetStoreDeliveryV2 {
+	config.DisallowUnknownFields = true
+	config.WriteV2Fields = true
+	return &PetStoreDeliveryV2Impl{
+		V1:            NewPetStoreDelivery(usecase, idempotency, config).(*PetStoreDeliveryImpl),
+		OwnerDelivery: NewOwnerDelivery(owners, config),
+	}
+}
+
+// FindPets Impl.
+// Unlike v1, pets are in PetList with the next cursor.
+func (impl *PetStoreDeliveryV2Impl) FindPets(w http.ResponseWriter, r *http.Request, params openapiv2.FindPetsParams) {
+	pets, cursor, err := impl.V1.findPets(w, r, openapi.FindPetsParams(params))
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	rslt := openapiv2.PetList{Items: []openapiv2.Pet{}}
+	for _, p := range *pets {
+		rslt.Items = append(rslt.Items, toPetV2(domain.Pet(p)))
+	}
+	if cursor != "" {
+		rslt.NextCursor = &cursor
+	}
+	write200OKWithETag(w, r, "", rslt)
+}
+
+// AddPet Impl.
+// Unlike v1, responds 201 with Location of the created Pet.
+func (impl *PetStoreDeliveryV2Impl) AddPet(w http.ResponseWriter, r *http.Request, params openapiv2.AddPetParams) {
+	impl.V1.idempotent(w, r, params.IdempotencyKey, func(w http.ResponseWriter) {
+		p, err := impl.V1.addPet(r)
+		if err != nil {
+			writeError(w, r, err)
+			return
+		}
+
+		write201Created(w, path.Join(r.URL.Path, strconv.FormatInt(p.Id, 10)), p)
+	})
+}
+
+// AddPets Impl.
+func (impl *PetStoreDeliveryV2Impl) AddPets(w http.ResponseWriter, r *http.Request, params openapiv2.AddPetsParams) {
+	impl.V1.AddPets(w, r, openapi.AddPetsParams(params))
+}
+
+// DeletePets Impl.
+func (impl *PetStoreDeliveryV2Impl) DeletePets(w http.ResponseWriter, r *http.Request, params openapiv2.DeletePetsParams) {
+	impl.V1.DeletePets(w, r, openapi.DeletePetsParams(params))
+}
+
+// DeletePet Impl.
+func (impl *PetStoreDeliveryV2Impl) DeletePet(w http.ResponseWriter, r *http.Request, id int64) {
+	impl.V1.DeletePet(w, r, id)
+}
+
+// FindPetById Impl.
+func (impl *PetStoreDeliveryV2Impl) FindPetById(w http.ResponseWriter, r *http.Request, id int64) {
+	impl.V1.FindPetById(w, r, id)
+}
+
+// RestorePet Impl.
+func (impl *PetStoreDeliveryV2Impl) RestorePet(w http.ResponseWriter, r *http.Request, id int64) {
+	impl.V1.RestorePet(w, r, id)
+}
+
+// UpdatePet Impl.
+func (impl *PetStoreDeliveryV2Impl) UpdatePet(w http.ResponseWriter, r *http.Request, id int64) {
+	impl.V1.UpdatePet(w, r, id)
+}
+
+// PatchPet Impl.
+func (impl *PetStoreDeliveryV2Impl) PatchPet(w http.ResponseWriter, r *http.Request, id int64) {
+	impl.V1.PatchPet(w, r, id)
+}
+
+// TransitionPet Impl.
+func (impl *PetStoreDeliveryV2Impl) TransitionPet(w http.ResponseWriter, r *http.Request, id int64) {
+	impl.V1.TransitionPet(w, r, id)
+}
+
+// FindPetTransitions Impl.
+func (impl *PetStoreDeliveryV2Impl) FindPetTransitions(w http.ResponseWriter, r *http.Request, id int64) {
+	impl.V1.FindPetTransitions(w, r, id)
+}
+
+// SearchPets Impl.
+func (impl *PetStoreDeliveryV2Impl) SearchPets(w http.ResponseWriter, r *http.Request, params openapiv2.SearchPetsParams) {
+	hits, cursor, err := impl.V1.searchPets(w, r, openapi.SearchPetsParams(params))
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	rslt := openapiv2.PetSearchResult{Items: []openapiv2.PetSearchHit{}}
+	for _, hit := range *hits {
+		rslt.Items = append(rslt.Items, openapiv2.PetSearchHit{Pet: toPetV2(hit.Pet), Highlight: hit.Highlight})
+	}
+	if cursor != "" {
+		rslt.NextCursor = &cursor
+	}
+	write200OK(w, rslt)
+}
+
+// FindTags Impl.
+func (impl *PetStoreDeliveryV2Impl) FindTags(w http.ResponseWriter, r *http.Request) {
+	impl.V1.FindTags(w, r)
+}
+
+// RenameTag Impl.
+func (impl *PetStoreDeliveryV2Impl) RenameTag(w http.ResponseWriter, r *http.Request) {
+	impl.V1.RenameTag(w, r)
+}
+
+// MergeTags Impl.
+func (impl *PetStoreDeliveryV2Impl) MergeTags(w http.ResponseWriter, r *http.Request) {
+	impl.V1.MergeTags(w, r)
+}
+
+func toPetV2(p domain.Pet) openapiv2.Pet {
+	rslt := openapiv2.Pet{Id: p.Id}
+	rslt.Name = p.Name
+	rslt.Tag = p.Tag
+	rslt.Tags = p.Tags
+	rslt.Species = p.Species
+	rslt.Breed = p.Breed
+	rslt.BirthDate = p.BirthDate
+	rslt.Sex = p.Sex
+	rslt.Status = p.Status
+	rslt.Attributes = p.Attributes
+	rslt.DeletedAt = p.DeletedAt
+	return rslt
+}
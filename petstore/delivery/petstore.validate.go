@@ -1,7 +1,8 @@
 package delivery
 
 import (
-	validation "github.com/go-ozzo/ozzo-validation"
+	"sort"
+
 	"github.com/opbls/scapo/petstore/domain"
 	"github.com/opbls/scapo/petstore/openapi"
 )
@@ -9,18 +10,54 @@ import (
 // Validate Fields.
 func validatePathParam(p openapi.FindPetsParams) error {
 	if p.Limit != nil && *p.Limit < 0 {
-		return domain.Err400BadRequest
+		return domain.Err400BadRequest.WithViolations(domain.Violation{Field: "limit", Code: "min", Message: "must be no less than 0"})
+	}
+	if p.IdGt != nil && *p.IdGt < 0 {
+		return domain.Err400BadRequest.WithViolations(domain.Violation{Field: "id_gt", Code: "min", Message: "must be no less than 0"})
+	}
+	if p.IdLt != nil && *p.IdLt < 0 {
+		return domain.Err400BadRequest.WithViolations(domain.Violation{Field: "id_lt", Code: "min", Message: "must be no less than 0"})
+	}
+	if p.BornAfter != nil && p.BornBefore != nil && !p.BornBefore.IsZero() && p.BornAfter.After(p.BornBefore.Time) {
+		return domain.Err400BadRequest.WithViolations(domain.Violation{Field: "born_before", Code: "min", Message: "must be no earlier than born_after"})
+	}
+	return nil
+}
+
+// Validate Fields.
+func validateSearchParam(p openapi.SearchPetsParams) error {
+	if p.Limit != nil && *p.Limit < 0 {
+		return domain.Err400BadRequest.WithViolations(domain.Violation{Field: "limit", Code: "min", Message: "must be no less than 0"})
 	}
 	return nil
 }
 
+// patchableFields are fields of PetPatch.
+var patchableFields = map[string]bool{
+	"name": true, "tag": true, "tags": true,
+	"species": true, "breed": true, "birth_date": true, "sex": true, "status": true, "attributes": true,
+}
+
 // Validate Fields.
-func validatePet(p domain.Pet) error {
-	err := validation.ValidateStruct(&p,
-		validation.Field(&p.Name, validation.Required),
-	)
-	if err != nil {
-		return domain.Err400BadRequest
+// Required fields can not be removed by patch, unknown fields are rejected if disallowUnknown.
+func validatePetPatch(p domain.PetPatch, disallowUnknown bool) error {
+	if v, ok := p["name"]; ok && v == nil {
+		return domain.Err400BadRequest.WithViolations(domain.Violation{Field: "name", Code: "required", Message: "cannot be removed"})
+	}
+	if v, ok := p["status"]; ok && v == nil {
+		return domain.Err400BadRequest.WithViolations(domain.Violation{Field: "status", Code: "required", Message: "cannot be removed"})
+	}
+	if disallowUnknown {
+		violations := []domain.Violation{}
+		for field := range p {
+			if !patchableFields[field] {
+				violations = append(violations, domain.Violation{Field: field, Code: "unknown", Message: "unknown field"})
+			}
+		}
+		if len(violations) > 0 {
+			sort.Slice(violations, func(i, j int) bool { return violations[i].Field < violations[j].Field })
+			return domain.Err400BadRequest.WithViolations(violations...)
+		}
 	}
 	return nil
 }
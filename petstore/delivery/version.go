@@ -0,0 +1,22 @@
+package delivery
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// Deprecated sets headers announcing the version is deprecated.
+//   - Deprecation: since when, as described in RFC 9745
+//   - Sunset: when it will be removed, as described in RFC 8594, omitted if zero
+func Deprecated(deprecation time.Time, sunset time.Time) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Deprecation", fmt.Sprintf("@%d", deprecation.Unix()))
+			if !sunset.IsZero() {
+				w.Header().Set("Sunset", sunset.UTC().Format(http.TimeFormat))
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
@@ -0,0 +1,50 @@
+package domain
+
+type (
+	// BatchMode is how a batch treats failed items.
+	BatchMode string
+
+	// BatchResult entity, results of items in the order of the request.
+	BatchResult struct {
+		// Committed is false if nothing is applied.
+		Committed bool
+		Items     []BatchItem
+	}
+
+	// BatchItem entity, the result of an item.
+	BatchItem struct {
+		ID int64
+		// Pet is the created Pet.
+		Pet *Pet
+		// Err is nil if applied, Err424FailedDependency if rolled back because another item failed.
+		Err error
+	}
+)
+
+const (
+	// BatchAtomic applies all items or nothing if any item fails.
+	BatchAtomic BatchMode = "atomic"
+	// BatchBestEffort applies valid items and reports the failed ones.
+	BatchBestEffort BatchMode = "best_effort"
+)
+
+// ParseBatchMode returns BatchMode of name, BatchAtomic if empty.
+func ParseBatchMode(name string) (BatchMode, error) {
+	switch m := BatchMode(name); m {
+	case "":
+		return BatchAtomic, nil
+	case BatchAtomic, BatchBestEffort:
+		return m, nil
+	}
+	return "", Err400BadRequest.WithViolations(Violation{Field: "mode", Code: "invalid", Message: "must be atomic or best_effort"})
+}
+
+// Failed reports any item failed.
+func (r *BatchResult) Failed() bool {
+	for _, item := range r.Items {
+		if item.Err != nil {
+			return true
+		}
+	}
+	return false
+}
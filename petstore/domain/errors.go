@@ -1,12 +1,134 @@
 package domain
 
-import "errors"
+import (
+	"net/http"
+	"sort"
+	"strings"
+
+	validation "github.com/go-ozzo/ozzo-validation"
+)
+
+type (
+	// Error is the error returned across layers.
+	// Errors with the same Code match with errors.Is, details and cause do not matter.
+	Error struct {
+		// Code is machine-readable, like "not_found".
+		Code string
+		// Status is HTTP status code.
+		Status int
+		// Message is safe to show to the client.
+		Message string
+		// Violations are field-level details.
+		Violations []Violation
+		// AllowedTransitions are transitions allowed from the current status of the Pet.
+		AllowedTransitions []string
+		// Cause is the wrapped error, not shown to the client.
+		Cause error
+	}
+
+	// Violation of a field.
+	Violation struct {
+		Field   string
+		Code    string
+		Message string
+	}
+)
 
 var (
 	// Err400BadRequest variable
-	Err400BadRequest = errors.New("Requested Parameter or Body Not Valid")
+	Err400BadRequest = &Error{Code: "bad_request", Status: http.StatusBadRequest, Message: "Requested Parameter or Body Not Valid"}
 	// Err404NotFound variable
-	Err404NotFound = errors.New("Requested Resource Not Found")
+	Err404NotFound = &Error{Code: "not_found", Status: http.StatusNotFound, Message: "Requested Resource Not Found"}
+	// Err409Conflict variable
+	Err409Conflict = &Error{Code: "conflict", Status: http.StatusConflict, Message: "Requested Resource Conflicts"}
+	// Err409TransitionNotAllowed variable
+	Err409TransitionNotAllowed = &Error{Code: "transition_not_allowed", Status: http.StatusConflict, Message: "Transition Not Allowed From The Current Status"}
+	// Err412PreconditionFailed variable
+	Err412PreconditionFailed = &Error{Code: "precondition_failed", Status: http.StatusPreconditionFailed, Message: "Requested Resource Has Been Modified"}
+	// Err413PayloadTooLarge variable
+	Err413PayloadTooLarge = &Error{Code: "payload_too_large", Status: http.StatusRequestEntityTooLarge, Message: "Request Body Too Large"}
+	// Err422UnprocessableEntity variable
+	Err422UnprocessableEntity = &Error{Code: "unprocessable", Status: http.StatusUnprocessableEntity, Message: "Requested Body Can Not Be Processed"}
+	// Err424FailedDependency variable
+	Err424FailedDependency = &Error{Code: "failed_dependency", Status: http.StatusFailedDependency, Message: "Not Applied Because Another Item Failed"}
+	// Err499ClientClosedRequest variable
+	Err499ClientClosedRequest = &Error{Code: "client_closed_request", Status: 499, Message: "Client Closed Request"}
 	// Err500InternalServerError variable
-	Err500InternalServerError = errors.New("Internal Server Error")
+	Err500InternalServerError = &Error{Code: "internal", Status: http.StatusInternalServerError, Message: "Internal Server Error"}
+	// Err503ServiceUnavailable variable
+	Err503ServiceUnavailable = &Error{Code: "unavailable", Status: http.StatusServiceUnavailable, Message: "Service Unavailable"}
+	// Err504GatewayTimeout variable
+	Err504GatewayTimeout = &Error{Code: "timeout", Status: http.StatusGatewayTimeout, Message: "Request Deadline Exceeded"}
 )
+
+// Error returns message with violations and cause, for logging.
+func (e *Error) Error() string {
+	s := e.Message
+	if len(e.Violations) > 0 {
+		vs := []string{}
+		for _, v := range e.Violations {
+			vs = append(vs, v.Field+": "+v.Message)
+		}
+		s += " (" + strings.Join(vs, "; ") + ")"
+	}
+	if e.Cause != nil {
+		s += ": " + e.Cause.Error()
+	}
+	return s
+}
+
+// Unwrap returns Cause.
+func (e *Error) Unwrap() error {
+	return e.Cause
+}
+
+// Is reports target has the same Code.
+func (e *Error) Is(target error) bool {
+	t, ok := target.(*Error)
+	return ok && t.Code == e.Code
+}
+
+// Wrap returns copy with cause.
+func (e *Error) Wrap(cause error) *Error {
+	c := *e
+	c.Cause = cause
+	return &c
+}
+
+// WithMessage returns copy with message.
+func (e *Error) WithMessage(message string) *Error {
+	c := *e
+	c.Message = message
+	return &c
+}
+
+// WithViolations returns copy with violations appended.
+func (e *Error) WithViolations(violations ...Violation) *Error {
+	c := *e
+	c.Violations = append(append([]Violation{}, e.Violations...), violations...)
+	return &c
+}
+
+// WithAllowedTransitions returns copy with allowed transitions.
+func (e *Error) WithAllowedTransitions(transitions ...string) *Error {
+	c := *e
+	c.AllowedTransitions = append([]string{}, transitions...)
+	return &c
+}
+
+// ViolationsOf returns Err400BadRequest with violations of ozzo-validation errors sorted by field, nil if err is nil.
+func ViolationsOf(err error) error {
+	if err == nil {
+		return nil
+	}
+	errs, ok := err.(validation.Errors)
+	if !ok {
+		return Err400BadRequest
+	}
+	violations := []Violation{}
+	for field, e := range errs {
+		violations = append(violations, Violation{Field: field, Code: "invalid", Message: e.Error()})
+	}
+	sort.Slice(violations, func(i, j int) bool { return violations[i].Field < violations[j].Field })
+	return Err400BadRequest.WithViolations(violations...)
+}
@@ -0,0 +1,48 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	validation "github.com/go-ozzo/ozzo-validation"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestError(t *testing.T) {
+	t.Run("SUCCESS_Is_Wrapped", func(t *testing.T) {
+		err := fmt.Errorf("query: %w", Err504GatewayTimeout.Wrap(context.DeadlineExceeded))
+		assert.True(t, errors.Is(err, Err504GatewayTimeout))
+		assert.True(t, errors.Is(err, context.DeadlineExceeded))
+		assert.False(t, errors.Is(err, Err500InternalServerError))
+	})
+
+	t.Run("SUCCESS_As_Violations", func(t *testing.T) {
+		var err error = Err400BadRequest.WithViolations(Violation{Field: "name", Code: "required", Message: "cannot be blank"})
+		e := &Error{}
+		assert.True(t, errors.As(err, &e))
+		assert.Equal(t, 400, e.Status)
+		assert.Equal(t, "bad_request", e.Code)
+		assert.Equal(t, "name", e.Violations[0].Field)
+		assert.True(t, errors.Is(err, Err400BadRequest))
+		// sentinel is not modified
+		assert.Empty(t, Err400BadRequest.Violations)
+	})
+
+	t.Run("SUCCESS_Error_Message", func(t *testing.T) {
+		err := Err500InternalServerError.Wrap(errors.New("disk I/O error"))
+		assert.Equal(t, "Internal Server Error: disk I/O error", err.Error())
+	})
+
+	t.Run("SUCCESS_ViolationsOf", func(t *testing.T) {
+		assert.Nil(t, ViolationsOf(nil))
+		err := ViolationsOf(validation.Errors{"species": errors.New("too long"), "name": errors.New("cannot be blank")})
+		e := &Error{}
+		if assert.True(t, errors.As(err, &e)) && assert.Len(t, e.Violations, 2) {
+			assert.Equal(t, "name", e.Violations[0].Field)
+			assert.Equal(t, "species", e.Violations[1].Field)
+		}
+		assert.Equal(t, Err400BadRequest, ViolationsOf(errors.New("not ozzo")))
+	})
+}
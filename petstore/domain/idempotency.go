@@ -0,0 +1,27 @@
+package domain
+
+import (
+	"time"
+)
+
+type (
+	// IdempotencyRecord entity, the response stored for an Idempotency-Key.
+	IdempotencyRecord struct {
+		// Scope is the operation the key is used for, like "POST /pets".
+		Scope string `db:"scope"`
+		Key   string `db:"key"`
+		// RequestHash tells whether the replayed request is the same.
+		RequestHash string `db:"request_hash"`
+		// Status is nil while the first request is in progress.
+		Status *int `db:"status"`
+		// Header is the response header to replay, like Location.
+		Header    map[string]string `db:"-"`
+		Body      []byte            `db:"body"`
+		CreatedAt time.Time         `db:"created_at"`
+	}
+)
+
+// Completed reports the response is stored.
+func (r *IdempotencyRecord) Completed() bool {
+	return r.Status != nil
+}
@@ -0,0 +1,29 @@
+package domain
+
+import (
+	"time"
+)
+
+type (
+	// Owner entity, a customer who adopts Pets.
+	Owner struct {
+		Id        int64     `db:"id" json:"id"`
+		Name      string    `db:"name" json:"name"`
+		Email     string    `db:"email" json:"email"`
+		Phone     *string   `db:"phone" json:"phone,omitempty"`
+		CreatedAt time.Time `db:"created_at" json:"created_at"`
+	}
+	// Owners entity, ordered by id.
+	Owners []Owner
+
+	// Adoption entity, a Pet adopted by an Owner.
+	// The Pet belongs to the Owner of the latest Adoption while it is adopted.
+	Adoption struct {
+		Id      int64 `db:"id" json:"id"`
+		PetId   int64 `db:"pet_id" json:"pet_id"`
+		OwnerId int64 `db:"owner_id" json:"owner_id"`
+		// Actor makes the adopt transition of the Pet.
+		Actor     string    `db:"actor" json:"actor"`
+		CreatedAt time.Time `db:"created_at" json:"created_at"`
+	}
+)
@@ -10,6 +10,37 @@ type (
 	Pet openapi.Pet
 	// Pets entity.
 	Pets []openapi.Pet
-	// QueryCondition entity.
-	QueryCondition map[string]interface{}
+	// PetPatch entity, a JSON Merge Patch (RFC 7386) document.
+	PetPatch map[string]interface{}
+
+	// VersionedPet is Pet with its version, incremented on each update.
+	// The version is the ETag of the Pet, not a field of the API.
+	VersionedPet struct {
+		Pet
+		Version int64 `db:"version" json:"-"`
+	}
+)
+
+// Statuses of Pets in the store, changed by PetTransitions.
+const (
+	PetStatusAvailable = "available"
+	PetStatusOnHold    = "on_hold"
+	PetStatusReserved  = "reserved"
+	PetStatusAdopted   = "adopted"
+)
+
+// Sexes of Pets.
+const (
+	PetSexMale    = "male"
+	PetSexFemale  = "female"
+	PetSexUnknown = "unknown"
+)
+
+var (
+	// PetStatuses are all statuses of Pets.
+	PetStatuses = []string{PetStatusAvailable, PetStatusOnHold, PetStatusReserved, PetStatusAdopted}
+	// PetInitialStatuses are statuses of new Pets.
+	PetInitialStatuses = []string{PetStatusAvailable, PetStatusOnHold}
+	// PetSexes are all sexes of Pets.
+	PetSexes = []string{PetSexMale, PetSexFemale, PetSexUnknown}
 )
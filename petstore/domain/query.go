@@ -0,0 +1,239 @@
+package domain
+
+import (
+	"time"
+)
+
+type (
+	// SortField is a Pet field Pets can be ordered by.
+	SortField string
+
+	// SortKey entity.
+	SortKey struct {
+		Field SortField
+		Desc  bool
+	}
+
+	// PetKeyset entity, sort key values of a Pet a page starts after.
+	PetKeyset struct {
+		ID   int64
+		Name string
+	}
+
+	// PetFilter entity. nil fields are not filtered.
+	PetFilter struct {
+		NamePrefix    *string
+		NameContains  *string
+		IDGreaterThan *int64
+		IDLessThan    *int64
+		HasTag        *bool
+		// Species and Breed are matched case insensitive.
+		Species *string
+		Breed   *string
+		Sex     *string
+		// Statuses matches Pets with any of them, any status if empty.
+		Statuses []string
+		// BornAfter and BornBefore are inclusive bounds of birth date.
+		BornAfter  *time.Time
+		BornBefore *time.Time
+		// IncludeDeleted lists deleted Pets as well, they are excluded by default.
+		IncludeDeleted bool
+	}
+
+	// PetQuery entity, query condition of Pets.
+	// Build it with PetQueryBuilder.
+	PetQuery struct {
+		Tags []string
+		// TagMatch is how Tags match, TagMatchAny if empty.
+		TagMatch TagMatch
+		Limit    int
+		After    *PetKeyset
+		Sort     []SortKey
+		Filter   PetFilter
+	}
+
+	// PetQueryBuilder builds PetQuery.
+	PetQueryBuilder struct {
+		query PetQuery
+		err   error
+	}
+)
+
+const (
+	// SortByID orders by id.
+	SortByID SortField = "id"
+	// SortByName orders by name.
+	SortByName SortField = "name"
+)
+
+// sortFields are fields allowed in sort.
+var sortFields = map[SortField]bool{
+	SortByID:   true,
+	SortByName: true,
+}
+
+// ParseSortField returns SortField of name.
+func ParseSortField(name string) (SortField, error) {
+	f := SortField(name)
+	if !sortFields[f] {
+		return "", Err400BadRequest
+	}
+	return f, nil
+}
+
+// NewPetQueryBuilder returns PetQueryBuilder.
+func NewPetQueryBuilder() *PetQueryBuilder {
+	return &PetQueryBuilder{}
+}
+
+// Tags filter by tag membership.
+func (b *PetQueryBuilder) Tags(tags ...string) *PetQueryBuilder {
+	b.query.Tags = append(b.query.Tags, tags...)
+	return b
+}
+
+// MatchTags sets how Tags match.
+func (b *PetQueryBuilder) MatchTags(m TagMatch) *PetQueryBuilder {
+	if m != TagMatchAny && m != TagMatchAll {
+		b.fail()
+	}
+	b.query.TagMatch = m
+	return b
+}
+
+// Limit the number of Pets.
+func (b *PetQueryBuilder) Limit(limit int) *PetQueryBuilder {
+	if limit < 0 {
+		b.fail()
+	}
+	b.query.Limit = limit
+	return b
+}
+
+// After starts after the Pet in the sort order.
+func (b *PetQueryBuilder) After(k PetKeyset) *PetQueryBuilder {
+	b.query.After = &k
+	return b
+}
+
+// SortBy appends sort key. A field can appear once.
+func (b *PetQueryBuilder) SortBy(field SortField, desc bool) *PetQueryBuilder {
+	if !sortFields[field] {
+		b.fail()
+	}
+	for _, k := range b.query.Sort {
+		if k.Field == field {
+			b.fail()
+		}
+	}
+	b.query.Sort = append(b.query.Sort, SortKey{Field: field, Desc: desc})
+	return b
+}
+
+// NamePrefix filter by name prefix.
+func (b *PetQueryBuilder) NamePrefix(prefix string) *PetQueryBuilder {
+	b.query.Filter.NamePrefix = &prefix
+	return b
+}
+
+// NameContains filter by name substring.
+func (b *PetQueryBuilder) NameContains(s string) *PetQueryBuilder {
+	b.query.Filter.NameContains = &s
+	return b
+}
+
+// IDGreaterThan filter by id lower bound.
+func (b *PetQueryBuilder) IDGreaterThan(id int64) *PetQueryBuilder {
+	if id < 0 {
+		b.fail()
+	}
+	b.query.Filter.IDGreaterThan = &id
+	return b
+}
+
+// IDLessThan filter by id upper bound.
+func (b *PetQueryBuilder) IDLessThan(id int64) *PetQueryBuilder {
+	if id < 0 {
+		b.fail()
+	}
+	b.query.Filter.IDLessThan = &id
+	return b
+}
+
+// HasTag filter by presence of any tag.
+func (b *PetQueryBuilder) HasTag(has bool) *PetQueryBuilder {
+	b.query.Filter.HasTag = &has
+	return b
+}
+
+// Species filter by species.
+func (b *PetQueryBuilder) Species(species string) *PetQueryBuilder {
+	b.query.Filter.Species = &species
+	return b
+}
+
+// Breed filter by breed.
+func (b *PetQueryBuilder) Breed(breed string) *PetQueryBuilder {
+	b.query.Filter.Breed = &breed
+	return b
+}
+
+// Sex filter by sex.
+func (b *PetQueryBuilder) Sex(sex string) *PetQueryBuilder {
+	if !ContainsString(PetSexes, sex) {
+		b.fail()
+	}
+	b.query.Filter.Sex = &sex
+	return b
+}
+
+// Statuses filter by any of statuses.
+func (b *PetQueryBuilder) Statuses(statuses ...string) *PetQueryBuilder {
+	for _, status := range statuses {
+		if !ContainsString(PetStatuses, status) {
+			b.fail()
+		}
+	}
+	b.query.Filter.Statuses = append(b.query.Filter.Statuses, statuses...)
+	return b
+}
+
+// BornAfter filter by birth date lower bound, inclusive.
+func (b *PetQueryBuilder) BornAfter(date time.Time) *PetQueryBuilder {
+	b.query.Filter.BornAfter = &date
+	return b
+}
+
+// BornBefore filter by birth date upper bound, inclusive.
+func (b *PetQueryBuilder) BornBefore(date time.Time) *PetQueryBuilder {
+	b.query.Filter.BornBefore = &date
+	return b
+}
+
+// IncludeDeleted lists deleted Pets as well.
+func (b *PetQueryBuilder) IncludeDeleted() *PetQueryBuilder {
+	b.query.Filter.IncludeDeleted = true
+	return b
+}
+
+// Build returns PetQuery, ordered by id at last so that the order is total.
+func (b *PetQueryBuilder) Build() (*PetQuery, error) {
+	if b.err != nil {
+		return nil, b.err
+	}
+
+	q := b.query
+	q.Sort = append([]SortKey{}, b.query.Sort...)
+	byID := false
+	for _, k := range q.Sort {
+		byID = byID || k.Field == SortByID
+	}
+	if !byID {
+		q.Sort = append(q.Sort, SortKey{Field: SortByID})
+	}
+	return &q, nil
+}
+
+func (b *PetQueryBuilder) fail() {
+	b.err = Err400BadRequest
+}
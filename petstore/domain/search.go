@@ -0,0 +1,37 @@
+package domain
+
+import (
+	"strings"
+)
+
+type (
+	// PetSearch entity, full-text search of Pets by name and tags.
+	PetSearch struct {
+		// Query is terms separated by spaces, Pets match all of them.
+		Query string
+		// Highlight sets PetSearchHit.Highlight.
+		Highlight bool
+		Limit     int
+		Offset    int
+	}
+
+	// PetSearchHit entity, a Pet matching PetSearch.
+	PetSearchHit struct {
+		Pet
+		// Highlight is the matching text HTML escaped with matched terms in <mark>, nil unless requested.
+		Highlight *string `db:"highlight"`
+	}
+	// PetSearchHits entity, ordered by relevance.
+	PetSearchHits []PetSearchHit
+)
+
+// HighlightStart and HighlightEnd enclose matched terms of PetSearchHit.Highlight.
+const (
+	HighlightStart = "<mark>"
+	HighlightEnd   = "</mark>"
+)
+
+// Terms returns terms of the query.
+func (s PetSearch) Terms() []string {
+	return strings.Fields(s.Query)
+}
@@ -0,0 +1,24 @@
+package domain
+
+// UniqueStrings returns ss without duplicates in the order.
+func UniqueStrings(ss []string) []string {
+	rslts := []string{}
+	seen := map[string]bool{}
+	for _, s := range ss {
+		if !seen[s] {
+			seen[s] = true
+			rslts = append(rslts, s)
+		}
+	}
+	return rslts
+}
+
+// ContainsString reports whether ss contains s.
+func ContainsString(ss []string, s string) bool {
+	for _, v := range ss {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,33 @@
+package domain
+
+import (
+	"github.com/opbls/scapo/petstore/openapi"
+)
+
+type (
+	// Tag entity, a tag with the number of Pets tagged.
+	Tag openapi.Tag
+	// Tags entity.
+	Tags []openapi.Tag
+
+	// TagMatch is how Pets are matched by tags of PetQuery.
+	TagMatch string
+)
+
+const (
+	// TagMatchAny matches Pets with any of the tags.
+	TagMatchAny TagMatch = "any"
+	// TagMatchAll matches Pets with all of the tags.
+	TagMatchAll TagMatch = "all"
+)
+
+// ParseTagMatch returns TagMatch of name, TagMatchAny if empty.
+func ParseTagMatch(name string) (TagMatch, error) {
+	switch m := TagMatch(name); m {
+	case "":
+		return TagMatchAny, nil
+	case TagMatchAny, TagMatchAll:
+		return m, nil
+	}
+	return "", Err400BadRequest.WithViolations(Violation{Field: "tag_match", Code: "invalid", Message: "must be any or all"})
+}
@@ -0,0 +1,72 @@
+package domain
+
+import (
+	"github.com/opbls/scapo/petstore/openapi"
+)
+
+type (
+	// PetTransition entity, a change of the status of a Pet with the actor and the time.
+	PetTransition openapi.PetTransition
+	// PetTransitions entity, oldest first.
+	PetTransitions []openapi.PetTransition
+)
+
+// Transitions of the status of Pets.
+const (
+	PetTransitionReserve = "reserve"
+	PetTransitionCancel  = "cancel"
+	PetTransitionAdopt   = "adopt"
+	PetTransitionHold    = "hold"
+	PetTransitionRelease = "release"
+	PetTransitionReturn  = "return"
+)
+
+// petTransitions is the state machine of the status of Pets, in the order of AllowedTransitions.
+//
+//	available --reserve--> reserved --adopt--> adopted
+//	available <--cancel--- reserved
+//	available <--return------------------------ adopted
+//	available --hold--> on_hold --release--> available
+var petTransitions = []struct {
+	Name string
+	From string
+	To   string
+}{
+	{PetTransitionReserve, PetStatusAvailable, PetStatusReserved},
+	{PetTransitionHold, PetStatusAvailable, PetStatusOnHold},
+	{PetTransitionRelease, PetStatusOnHold, PetStatusAvailable},
+	{PetTransitionAdopt, PetStatusReserved, PetStatusAdopted},
+	{PetTransitionCancel, PetStatusReserved, PetStatusAvailable},
+	{PetTransitionReturn, PetStatusAdopted, PetStatusAvailable},
+}
+
+// PetTransitionNames are all transitions.
+func PetTransitionNames() []string {
+	rslts := []string{}
+	for _, t := range petTransitions {
+		rslts = append(rslts, t.Name)
+	}
+	return rslts
+}
+
+// AllowedTransitions returns transitions allowed from status.
+func AllowedTransitions(status string) []string {
+	rslts := []string{}
+	for _, t := range petTransitions {
+		if t.From == status {
+			rslts = append(rslts, t.Name)
+		}
+	}
+	return rslts
+}
+
+// TransitionTo returns the status after transition from status,
+// Err409TransitionNotAllowed with the allowed transitions if it is not allowed.
+func TransitionTo(status string, transition string) (string, error) {
+	for _, t := range petTransitions {
+		if t.From == status && t.Name == transition {
+			return t.To, nil
+		}
+	}
+	return "", Err409TransitionNotAllowed.WithAllowedTransitions(AllowedTransitions(status)...)
+}
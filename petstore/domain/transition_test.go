@@ -0,0 +1,44 @@
+package domain
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTransitionTo(t *testing.T) {
+	t.Run("SUCCESS_Lifecycle", func(t *testing.T) {
+		status := PetStatusAvailable
+		for _, c := range []struct{ transition, to string }{
+			{PetTransitionReserve, PetStatusReserved},
+			{PetTransitionCancel, PetStatusAvailable},
+			{PetTransitionHold, PetStatusOnHold},
+			{PetTransitionRelease, PetStatusAvailable},
+			{PetTransitionReserve, PetStatusReserved},
+			{PetTransitionAdopt, PetStatusAdopted},
+			{PetTransitionReturn, PetStatusAvailable},
+		} {
+			to, err := TransitionTo(status, c.transition)
+			if assert.NoError(t, err, c.transition) {
+				assert.Equal(t, c.to, to, c.transition)
+			}
+			status = to
+		}
+	})
+
+	// abnormal 409
+	t.Run("ABNORMAL_Not_Allowed", func(t *testing.T) {
+		_, err := TransitionTo(PetStatusAdopted, PetTransitionReserve)
+		assert.True(t, errors.Is(err, Err409TransitionNotAllowed))
+		e := &Error{}
+		if assert.True(t, errors.As(err, &e)) {
+			assert.Equal(t, []string{PetTransitionReturn}, e.AllowedTransitions)
+		}
+		// sentinel is not modified
+		assert.Empty(t, Err409TransitionNotAllowed.AllowedTransitions)
+
+		_, err = TransitionTo(PetStatusOnHold, "sell")
+		assert.True(t, errors.Is(err, Err409TransitionNotAllowed))
+	})
+}
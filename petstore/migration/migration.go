@@ -0,0 +1,246 @@
+package migration
+
+import (
+	"context"
+	"embed"
+	"fmt"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// files are versioned scripts named like 0001_create_petstore.up.sql and 0001_create_petstore.down.sql.
+//
+//go:embed sql/*.sql
+var files embed.FS
+
+type (
+	// Migrator interface.
+	Migrator interface {
+		Up(ctx context.Context) ([]Migration, error)
+		Down(ctx context.Context) (*Migration, error)
+		Status(ctx context.Context) ([]Status, error)
+		Version(ctx context.Context) (int, error)
+		Latest() int
+	}
+
+	// MigratorImpl struct.
+	MigratorImpl struct {
+		DB         *sqlx.DB
+		Migrations []Migration
+	}
+
+	// Migration is a versioned pair of scripts.
+	Migration struct {
+		Version int
+		Name    string
+		Up      string
+		Down    string
+	}
+
+	// Status of a Migration.
+	Status struct {
+		Migration
+		AppliedAt *time.Time
+	}
+)
+
+// NewMigrator instantiate Migrator with the embedded migrations.
+func NewMigrator(db *sqlx.DB) (Migrator, error) {
+	migrations, err := load()
+	if err != nil {
+		return nil, err
+	}
+	return &MigratorImpl{
+		DB:         db,
+		Migrations: migrations,
+	}, nil
+}
+
+// Up applies pending migrations in version order and returns applied ones.
+func (impl *MigratorImpl) Up(ctx context.Context) ([]Migration, error) {
+	applied, err := impl.applied(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	rslts := []Migration{}
+	for _, m := range impl.Migrations {
+		if _, ok := applied[m.Version]; ok {
+			continue
+		}
+		err := impl.run(ctx, m.Up,
+			`INSERT INTO schema_migrations(version, name, applied_at) VALUES(?, ?, ?)`, m.Version, m.Name, time.Now().UTC())
+		if err != nil {
+			return rslts, fmt.Errorf("migration %04d_%s up: %w", m.Version, m.Name, err)
+		}
+		rslts = append(rslts, m)
+	}
+	return rslts, nil
+}
+
+// Down reverts the latest applied migration, nil if nothing is applied.
+func (impl *MigratorImpl) Down(ctx context.Context) (*Migration, error) {
+	version, err := impl.Version(ctx)
+	if err != nil || version == 0 {
+		return nil, err
+	}
+
+	for i := range impl.Migrations {
+		m := impl.Migrations[i]
+		if m.Version != version {
+			continue
+		}
+		err := impl.run(ctx, m.Down,
+			`DELETE FROM schema_migrations WHERE version = ?`, m.Version)
+		if err != nil {
+			return nil, fmt.Errorf("migration %04d_%s down: %w", m.Version, m.Name, err)
+		}
+		return &m, nil
+	}
+	return nil, fmt.Errorf("migration %04d is applied but not known", version)
+}
+
+// Status returns every migration with its applied time.
+func (impl *MigratorImpl) Status(ctx context.Context) ([]Status, error) {
+	applied, err := impl.applied(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	rslts := []Status{}
+	for _, m := range impl.Migrations {
+		s := Status{Migration: m}
+		if at, ok := applied[m.Version]; ok {
+			s.AppliedAt = &at
+		}
+		rslts = append(rslts, s)
+	}
+	return rslts, nil
+}
+
+// Version returns the latest applied version, 0 if nothing is applied.
+// It only reads, schema_migrations is not created.
+func (impl *MigratorImpl) Version(ctx context.Context) (int, error) {
+	var exists bool
+	err := impl.DB.GetContext(ctx, &exists, `SELECT EXISTS (SELECT 1 FROM sqlite_master WHERE type = 'table' AND name = 'schema_migrations')`)
+	if err != nil || !exists {
+		return 0, err
+	}
+
+	version := 0
+	err = impl.DB.GetContext(ctx, &version, `SELECT COALESCE(MAX(version), 0) FROM schema_migrations`)
+	if err != nil {
+		return 0, err
+	}
+	return version, nil
+}
+
+// Latest returns the latest known version.
+func (impl *MigratorImpl) Latest() int {
+	if len(impl.Migrations) == 0 {
+		return 0
+	}
+	return impl.Migrations[len(impl.Migrations)-1].Version
+}
+
+// run executes script and bookkeeping statement in a transaction.
+func (impl *MigratorImpl) run(ctx context.Context, script string, bookkeeping string, args ...interface{}) error {
+	tx, err := impl.DB.BeginTxx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, script); err != nil {
+		return err
+	}
+	if _, err := tx.ExecContext(ctx, bookkeeping, args...); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// applied returns applied versions and their applied time.
+func (impl *MigratorImpl) applied(ctx context.Context) (map[int]time.Time, error) {
+	if err := impl.ensureTable(ctx); err != nil {
+		return nil, err
+	}
+
+	rows := []struct {
+		Version   int       `db:"version"`
+		AppliedAt time.Time `db:"applied_at"`
+	}{}
+	err := impl.DB.SelectContext(ctx, &rows, `SELECT version, applied_at FROM schema_migrations`)
+	if err != nil {
+		return nil, err
+	}
+
+	rslts := map[int]time.Time{}
+	for _, row := range rows {
+		rslts[row.Version] = row.AppliedAt
+	}
+	return rslts, nil
+}
+
+func (impl *MigratorImpl) ensureTable(ctx context.Context) error {
+	_, err := impl.DB.ExecContext(ctx, `CREATE TABLE IF NOT EXISTS schema_migrations(
+		version integer PRIMARY KEY
+		, name text NOT NULL
+		, applied_at timestamp NOT NULL
+	)`)
+	return err
+}
+
+// load returns embedded migrations in version order.
+func load() ([]Migration, error) {
+	entries, err := files.ReadDir("sql")
+	if err != nil {
+		return nil, err
+	}
+
+	byVersion := map[int]*Migration{}
+	for _, e := range entries {
+		// 0001_create_petstore.up.sql
+		base := strings.TrimSuffix(e.Name(), ".sql")
+		direction := path.Ext(base)
+		base = strings.TrimSuffix(base, direction)
+		sep := strings.Index(base, "_")
+		if sep < 0 || (direction != ".up" && direction != ".down") {
+			return nil, fmt.Errorf("migration file name %q is not like 0001_name.up.sql", e.Name())
+		}
+		version, err := strconv.Atoi(base[:sep])
+		if err != nil || version <= 0 {
+			return nil, fmt.Errorf("migration file name %q has no version", e.Name())
+		}
+
+		b, err := files.ReadFile(path.Join("sql", e.Name()))
+		if err != nil {
+			return nil, err
+		}
+		m, ok := byVersion[version]
+		if !ok {
+			m = &Migration{Version: version, Name: base[sep+1:]}
+			byVersion[version] = m
+		}
+		if direction == ".up" {
+			m.Up = string(b)
+		} else {
+			m.Down = string(b)
+		}
+	}
+
+	rslts := []Migration{}
+	for _, m := range byVersion {
+		if m.Up == "" || m.Down == "" {
+			return nil, fmt.Errorf("migration %04d_%s needs both up and down", m.Version, m.Name)
+		}
+		rslts = append(rslts, *m)
+	}
+	sort.Slice(rslts, func(i, j int) bool { return rslts[i].Version < rslts[j].Version })
+	return rslts, nil
+}
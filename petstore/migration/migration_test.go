@@ -0,0 +1,145 @@
+package migration
+
+import (
+	"context"
+	"testing"
+
+	"github.com/jmoiron/sqlx"
+	// sqlite driver
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMigrator(t *testing.T) {
+	ctx := context.Background()
+
+	db, _ := sqlx.Connect("sqlite3", ":memory:")
+	defer db.Close()
+	db.SetMaxOpenConns(1)
+
+	m, err := NewMigrator(db)
+	if !assert.NoError(t, err) {
+		return
+	}
+	latest := m.Latest()
+	assert.Greater(t, latest, 0)
+
+	t.Run("SUCCESS_Up", func(t *testing.T) {
+		applied, err := m.Up(ctx)
+		assert.NoError(t, err)
+		assert.Equal(t, latest, applied[len(applied)-1].Version)
+
+		version, err := m.Version(ctx)
+		assert.NoError(t, err)
+		assert.Equal(t, latest, version)
+
+		_, err = db.Exec(`INSERT INTO petstore(name) VALUES('foo')`)
+		assert.NoError(t, err)
+	})
+
+	t.Run("SUCCESS_Up_Idempotent", func(t *testing.T) {
+		applied, err := m.Up(ctx)
+		assert.NoError(t, err)
+		assert.Equal(t, 0, len(applied))
+	})
+
+	t.Run("SUCCESS_Status", func(t *testing.T) {
+		status, err := m.Status(ctx)
+		assert.NoError(t, err)
+		for _, s := range status {
+			assert.NotNil(t, s.AppliedAt, "%04d_%s", s.Version, s.Name)
+		}
+	})
+
+	t.Run("SUCCESS_Down_All", func(t *testing.T) {
+		for version := latest; version > 0; {
+			reverted, err := m.Down(ctx)
+			if !assert.NoError(t, err) || !assert.NotNil(t, reverted) {
+				return
+			}
+			assert.Equal(t, version, reverted.Version)
+			version, _ = m.Version(ctx)
+		}
+
+		reverted, err := m.Down(ctx)
+		assert.NoError(t, err)
+		assert.Nil(t, reverted)
+
+		_, err = db.Exec(`SELECT id FROM petstore`)
+		assert.Error(t, err)
+	})
+
+	t.Run("SUCCESS_Up_Again", func(t *testing.T) {
+		_, err := m.Up(ctx)
+		assert.NoError(t, err)
+
+		version, _ := m.Version(ctx)
+		assert.Equal(t, latest, version)
+	})
+}
+
+func TestMigrateTags(t *testing.T) {
+	ctx := context.Background()
+
+	db, _ := sqlx.Connect("sqlite3", ":memory:")
+	defer db.Close()
+	db.SetMaxOpenConns(1)
+
+	m, _ := NewMigrator(db)
+	if _, err := m.Up(ctx); err != nil {
+		t.Fatal(err)
+	}
+	for version, _ := m.Version(ctx); version > 4; version, _ = m.Version(ctx) {
+		if _, err := m.Down(ctx); err != nil {
+			t.Fatal(err)
+		}
+	}
+	db.MustExec(`INSERT INTO petstore(id, name, tag) VALUES(1, 'foo', 'cat'), (2, 'bar', NULL), (3, 'baz', 'cat'), (4, 'qux', 'dog'), (5, 'quux', '')`)
+
+	tagsOf := func() map[int]string {
+		rows := []struct {
+			PetID int    `db:"pet_id"`
+			Name  string `db:"name"`
+		}{}
+		db.Select(&rows, `SELECT pt.pet_id, t.name FROM pet_tags pt JOIN tags t ON t.id = pt.tag_id ORDER BY pt.pet_id, pt.position`)
+		rslt := map[int]string{}
+		for _, row := range rows {
+			rslt[row.PetID] += row.Name + ","
+		}
+		return rslt
+	}
+
+	t.Run("SUCCESS_Up", func(t *testing.T) {
+		_, err := m.Up(ctx)
+		assert.NoError(t, err)
+		assert.Equal(t, map[int]string{1: "cat,", 3: "cat,", 4: "dog,"}, tagsOf())
+
+		var n int
+		db.Get(&n, `SELECT COUNT(*) FROM petstore`)
+		assert.Equal(t, 5, n)
+		// an empty tag is no tag
+		db.Get(&n, `SELECT COUNT(*) FROM tags WHERE name = ''`)
+		assert.Equal(t, 0, n)
+	})
+
+	t.Run("SUCCESS_Down_First_Tag", func(t *testing.T) {
+		db.MustExec(`INSERT INTO tags(name) VALUES('bird')`)
+		db.MustExec(`INSERT INTO pet_tags(pet_id, tag_id, position) SELECT 2, id, 1 FROM tags WHERE name = 'dog'`)
+		db.MustExec(`INSERT INTO pet_tags(pet_id, tag_id, position) SELECT 2, id, 0 FROM tags WHERE name = 'bird'`)
+
+		for version, _ := m.Version(ctx); version > 4; version, _ = m.Version(ctx) {
+			if _, err := m.Down(ctx); !assert.NoError(t, err) {
+				return
+			}
+		}
+
+		tags := []*string{}
+		db.Select(&tags, `SELECT tag FROM petstore WHERE tag IS NOT NULL ORDER BY id`)
+		if assert.Equal(t, 4, len(tags)) {
+			assert.Equal(t, "cat", *tags[0])
+			assert.Equal(t, "bird", *tags[1])
+			assert.Equal(t, "cat", *tags[2])
+			assert.Equal(t, "dog", *tags[3])
+		}
+	})
+}
@@ -0,0 +1,5 @@
+CREATE TABLE IF NOT EXISTS petstore(
+    id integer PRIMARY KEY autoincrement
+    , name text NOT NULL
+    , tag text
+);
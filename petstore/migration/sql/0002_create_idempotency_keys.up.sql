@@ -0,0 +1,11 @@
+CREATE TABLE IF NOT EXISTS idempotency_keys(
+    scope text NOT NULL
+    , key text NOT NULL
+    , request_hash text NOT NULL
+    , status integer
+    , header text
+    , body blob
+    , created_at timestamp NOT NULL
+    , PRIMARY KEY(scope, key)
+);
+CREATE INDEX IF NOT EXISTS idempotency_keys_created_at ON idempotency_keys(created_at);
@@ -0,0 +1,9 @@
+-- sqlite before 3.35 can not drop columns, the table is rebuilt
+CREATE TABLE petstore_0002(
+    id integer PRIMARY KEY autoincrement
+    , name text NOT NULL
+    , tag text
+);
+INSERT INTO petstore_0002(id, name, tag) SELECT id, name, tag FROM petstore;
+DROP TABLE petstore;
+ALTER TABLE petstore_0002 RENAME TO petstore;
@@ -0,0 +1,2 @@
+-- version is incremented on each update, for ETag and optimistic concurrency control
+ALTER TABLE petstore ADD COLUMN version integer NOT NULL DEFAULT 1;
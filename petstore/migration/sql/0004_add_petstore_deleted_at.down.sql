@@ -0,0 +1,12 @@
+-- sqlite before 3.35 can not drop columns, the table is rebuilt
+-- pets in the trash are dropped, they would be restored otherwise
+DROP INDEX IF EXISTS petstore_deleted_at;
+CREATE TABLE petstore_0003(
+    id integer PRIMARY KEY autoincrement
+    , name text NOT NULL
+    , tag text
+    , version integer NOT NULL DEFAULT 1
+);
+INSERT INTO petstore_0003(id, name, tag, version) SELECT id, name, tag, version FROM petstore WHERE deleted_at IS NULL;
+DROP TABLE petstore;
+ALTER TABLE petstore_0003 RENAME TO petstore;
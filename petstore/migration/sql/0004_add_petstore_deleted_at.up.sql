@@ -0,0 +1,3 @@
+-- deleted pets are kept in the trash until purged
+ALTER TABLE petstore ADD COLUMN deleted_at timestamp;
+CREATE INDEX IF NOT EXISTS petstore_deleted_at ON petstore(deleted_at);
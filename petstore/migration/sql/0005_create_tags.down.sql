@@ -0,0 +1,20 @@
+-- only the first tag of each pet is kept
+DROP INDEX IF EXISTS petstore_deleted_at;
+CREATE TABLE petstore_0004(
+    id integer PRIMARY KEY autoincrement
+    , name text NOT NULL
+    , tag text
+    , version integer NOT NULL DEFAULT 1
+    , deleted_at timestamp
+);
+INSERT INTO petstore_0004(id, name, tag, version, deleted_at)
+SELECT p.id, p.name
+    , (SELECT t.name FROM pet_tags pt JOIN tags t ON t.id = pt.tag_id WHERE pt.pet_id = p.id ORDER BY pt.position LIMIT 1)
+    , p.version, p.deleted_at
+FROM petstore p;
+DROP TABLE petstore;
+ALTER TABLE petstore_0004 RENAME TO petstore;
+CREATE INDEX IF NOT EXISTS petstore_deleted_at ON petstore(deleted_at);
+DROP INDEX IF EXISTS pet_tags_tag_id;
+DROP TABLE IF EXISTS pet_tags;
+DROP TABLE IF EXISTS tags;
@@ -0,0 +1,26 @@
+-- a pet has tags in the order of position
+CREATE TABLE IF NOT EXISTS tags(
+    id integer PRIMARY KEY autoincrement
+    , name text NOT NULL UNIQUE
+);
+CREATE TABLE IF NOT EXISTS pet_tags(
+    pet_id integer NOT NULL
+    , tag_id integer NOT NULL
+    , position integer NOT NULL
+    , PRIMARY KEY(pet_id, tag_id)
+);
+CREATE INDEX IF NOT EXISTS pet_tags_tag_id ON pet_tags(tag_id);
+INSERT INTO tags(name) SELECT DISTINCT tag FROM petstore WHERE tag IS NOT NULL AND tag <> '' ORDER BY tag;
+INSERT INTO pet_tags(pet_id, tag_id, position) SELECT p.id, t.id, 0 FROM petstore p JOIN tags t ON t.name = p.tag;
+-- sqlite before 3.35 can not drop columns, the table is rebuilt
+DROP INDEX IF EXISTS petstore_deleted_at;
+CREATE TABLE petstore_0005(
+    id integer PRIMARY KEY autoincrement
+    , name text NOT NULL
+    , version integer NOT NULL DEFAULT 1
+    , deleted_at timestamp
+);
+INSERT INTO petstore_0005(id, name, version, deleted_at) SELECT id, name, version, deleted_at FROM petstore;
+DROP TABLE petstore;
+ALTER TABLE petstore_0005 RENAME TO petstore;
+CREATE INDEX IF NOT EXISTS petstore_deleted_at ON petstore(deleted_at);
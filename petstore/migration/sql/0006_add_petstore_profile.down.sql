@@ -0,0 +1,14 @@
+-- sqlite before 3.35 can not drop columns, the table is rebuilt
+DROP INDEX IF EXISTS petstore_species;
+DROP INDEX IF EXISTS petstore_status;
+DROP INDEX IF EXISTS petstore_deleted_at;
+CREATE TABLE petstore_0005(
+    id integer PRIMARY KEY autoincrement
+    , name text NOT NULL
+    , version integer NOT NULL DEFAULT 1
+    , deleted_at timestamp
+);
+INSERT INTO petstore_0005(id, name, version, deleted_at) SELECT id, name, version, deleted_at FROM petstore;
+DROP TABLE petstore;
+ALTER TABLE petstore_0005 RENAME TO petstore;
+CREATE INDEX IF NOT EXISTS petstore_deleted_at ON petstore(deleted_at);
@@ -0,0 +1,10 @@
+-- profile of pets, attributes is a JSON object
+-- birth_date is text of YYYY-MM-DD, not date, which the driver would convert to time
+ALTER TABLE petstore ADD COLUMN species text;
+ALTER TABLE petstore ADD COLUMN breed text;
+ALTER TABLE petstore ADD COLUMN birth_date text;
+ALTER TABLE petstore ADD COLUMN sex text;
+ALTER TABLE petstore ADD COLUMN status text NOT NULL DEFAULT 'available';
+ALTER TABLE petstore ADD COLUMN attributes text;
+CREATE INDEX IF NOT EXISTS petstore_status ON petstore(status);
+CREATE INDEX IF NOT EXISTS petstore_species ON petstore(species COLLATE NOCASE);
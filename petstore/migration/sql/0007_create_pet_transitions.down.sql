@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS pet_transitions_pet_id;
+DROP TABLE IF EXISTS pet_transitions;
+UPDATE petstore SET status = 'pending' WHERE status = 'reserved';
+UPDATE petstore SET status = 'available' WHERE status = 'on_hold';
@@ -0,0 +1,12 @@
+-- status changes by transitions, pending is renamed to reserved
+CREATE TABLE IF NOT EXISTS pet_transitions(
+    id integer PRIMARY KEY autoincrement
+    , pet_id integer NOT NULL
+    , transition text NOT NULL
+    , from_status text NOT NULL
+    , to_status text NOT NULL
+    , actor text NOT NULL
+    , created_at timestamp NOT NULL
+);
+CREATE INDEX IF NOT EXISTS pet_transitions_pet_id ON pet_transitions(pet_id);
+UPDATE petstore SET status = 'reserved' WHERE status = 'pending';
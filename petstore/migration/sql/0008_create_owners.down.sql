@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS adoptions_owner_id;
+DROP INDEX IF EXISTS adoptions_pet_id;
+DROP TABLE IF EXISTS adoptions;
+DROP TABLE IF EXISTS owners;
@@ -0,0 +1,17 @@
+-- owners adopt pets, a pet belongs to the owner of its latest adoption while adopted
+CREATE TABLE IF NOT EXISTS owners(
+    id integer PRIMARY KEY autoincrement
+    , name text NOT NULL
+    , email text NOT NULL UNIQUE COLLATE NOCASE
+    , phone text
+    , created_at timestamp NOT NULL
+);
+CREATE TABLE IF NOT EXISTS adoptions(
+    id integer PRIMARY KEY autoincrement
+    , pet_id integer NOT NULL
+    , owner_id integer NOT NULL
+    , actor text NOT NULL
+    , created_at timestamp NOT NULL
+);
+CREATE INDEX IF NOT EXISTS adoptions_pet_id ON adoptions(pet_id);
+CREATE INDEX IF NOT EXISTS adoptions_owner_id ON adoptions(owner_id);
@@ -16,33 +16,92 @@ import (
 // Base64 encoded, gzipped, json marshaled Swagger object
 var swaggerSpec = []string{
 
-	"H4sIAAAAAAAC/+RXT48bufH9KgX+fsdOa2IvctApXo8XEJC1J5kkl7UPJbJaKoN/esiixoOBvntQ7NY/",
-	"j9bGIkGwQC4zUotkvXr1qvj62dgUxhQpSjHLZ1PslgK2j+9yTlk/jDmNlIWpPbbJkf4fUg4oZmk4yutX",
-	"pjPyNNL0lTaUzb4zgUrBTVs9/1gkc9yY/b4zmR4qZ3Jm+ct05mn9p+Nhaf2ZrOhZ7+nxjuQlnIjhWoDO",
-	"CG6+H7jtvhZujoXefxjM8pdn8/+ZBrM0/7c48bWYyVrM2Pbd1+DYfc3Un364wtRXoNhdgfRpr8s4Dmkq",
-	"QhS0DSIFZG+WBkcWwvDn8oibDeWek+lmdsz99Aze3K3g74TBdKZm3bQVGZeLxdmefWccFZt5FE7RLM0b",
-	"KBhGT22zbFGgFiqAMJIUSZkAC2AE+jItkwSOQopFMgrBQCg1UwGOIFuCDyNFPel1fwNlJMsDW2yhOuPZ",
-	"Uix0Kqt5M6LdErzqby4gl+Vi8fj42GP7uU95s5j3lsVfVm/fvb9/94dX/U2/leCbFiiH8mG4p7xjS9fy",
-	"XrQlCy0Oiz/n7G5O03RmR7lMpPyxv+lv9OQ0UsSRzdK8bo86M6JsW/EXSpB+2ExauqT1byQ1xwLofWMS",
-	"hpxCY6g8FaEwUa3fa6EMWyXZWioFJH2M7zFAIQc2RceBotQAVKSHn5EsRSwgFMaUoeCGRbhAwZEpdhDJ",
-	"Qt6maGuBQuFsAQtgIOnhDUXCCCiwybhjh4B1U6kDtMBoq+e2tYe3NeOapWZIjhP4lCl0kHLETEAbEiBP",
-	"M7pItgNbc6kF2IEnK7X0cFu5QGCQmkcuHYzV7zhi1liUkybdgXC07GoU2GHmWuBzLZJ6WEXYooWtgsBS",
-	"CEaPQgiOrdSgdKymFtNc0PHIxXLcAEbRbE65e95Uj8fMxy1mkowHEnU9hOSpCBNwGCk7Vqb+yTsMU0Lo",
-	"+aFiAMeozGQs8KC57cizQEwRJGVJWSnhgaI7Ru/hLiMViqIwKXI4Aag5IuySrzKiwI4iRVTAE7n6J2DN",
-	"esYqnk4eKM+sD2jZc7kI0iLon+5UXwslOfSkhXWd8mgpo2hi+r+H+1pGio6VZY8qHpd8yp0qsJAVVXPL",
-	"sklFs+5gR1u21SPooMuuBvC8ppx6+DnlNQNVLiG58zLoz03YHi1Hxv5jvCfX6lALDKTS82mdcltO6aSX",
-	"XCXX0IN2RsB23Ew9F98B1YtemQoOvqoKVZs93G2xkPdTW4yU5+2N5FZcEhiwWl7XiW48xNF15/t35OfC",
-	"8Y5yxu4ytHYJsOuObRh5ve3hHwIjeU9RqDxUgjGVStpHhxbqQanAQw9oyx2YPJx0SKvx2DUgR1HEGi1I",
-	"5iKaC+xYkHr4qRZLQNJmgat87AGdE8WSp8wNzqTew4agWqnYpGNrKBgh4EZTJj9Xq4e/1mlrSF7rNlWP",
-	"6qScE5TuOHoAq9UWmVbO4pzSnqUxj5hjL6pUtMDAsTtBmds2cuED4KIYLEt1rFBLQahyUNlcyCnSBWkt",
-	"Xg9354VpzM0Yx0zCNZzNrUk0tTtTtw7e/qNecOoN2mW3cmZpfuLo9HZpl0ZWAiiXZjYurwrBjU59GNgL",
-	"ZVg/GTUCZmkeKuWn0y2v60w3W7hmP4RCuW6OpgeYMz7p9yJP7dJTq9J8zCWCgF846BCvYU0Z0gCZSvXS",
-	"YOV2k/0KJs+B5QLUd33j/pPaoTLqYGnoX93cHDwPxcmWjaOfbcPic1GIz9fS/pZnmwzbV0TsX7ifkQQO",
-	"YCZvNGD18pvwfAvGZLKvBK6Rvow6WHUCH9eMqVzxEm8zoTRPFulR3cTBbDXjopfsBE+XqF/zPj2Se6HH",
-	"N07laCYzSkV+TO7pP5bowSO/zPSORGWEzum/I2xzboolV9r/m7L4rhp+59Xfd5OlXDyz208i8CT0Ug7T",
-	"c5VD4bjx1BSxRp2VadLF6hZKVdRXVHDbdk9C+OZYWt3qIBin6s1Y5iGgHvg0A9i9qOWvDYTrr0cvB8IP",
-	"L7NWIBMK9zto1G97/snTH0tyLNTqtgMeTq7fJSoQk8AWd3Ty/23B2Cp09Ub58WnlflP1BhK7/a8V73+s",
-	"bfV6pbw7lOHixfvwDt2fvYnq6+T+0/5fAQAA//9WyUg8mhEAAA==",
+	"H4sIAAAAAAAC/+xce3McN47/Kqi+/WNz2xrJspPdVdXWnRMnFd06js9W9q5qlVNxujEzjNhkm4+RpnL6",
+	"7lcA2a+ZnocUOxcr/sfWdLNJEAR+AAGQP2eFqWqjUXuXnf2cuWKBleA/vxS+WJx7rN6gC8rTo9qaGq2X",
+	"yA3QWmPpjz9YnGVn2b8cd30dp46OX1szVVhld3kmS2o8M7YSPjvLpPZfPMvyzK9qjD9xjpYb6hJvqW2J",
+	"rrCy9tJoblHiLZgZ+AWC9FiB1Py3xXcBnR/tq0a/l0T01NJ54YPbHPbbi4vXEF/2B8/h9OQJFBaFx5J+",
+	"PIMSFfKPZycnIPVSKMk/noE2HmYm6DK/1MbCs9P4SNS1kljCFAsRHILQxi/QxsnNhFRY0hyFN5UsoDIl",
+	"XuqRWd7lGbFAWiyzs38m9rUT+rH9wEx/woLnymu7bV0LU1XSeyw3eTETyiHIGVG/kHoO0jWTyHuz2DGB",
+	"jvypMQqFzph6ooQHpy/dvhVbF827tlNhrVhtcKSbUTfWGFu+biR6nSElrovu09NRcavQOTHn1uml81bq",
+	"+QhJzIum/Rg1r/DmNfrNRVg+AYs+WO3AaLUCLSpieglezHOWzyhFM4mqdCAswo2VHrl1lq9NTnhv5TR4",
+	"HBH9IjhvKuia5CDgP95+/woilaQPoQZv4PMTuMaVYzKenfz1C5iuPLq+tKZ55RnT8j2RcuZtwLs8m0rr",
+	"F1el8LhJAj2lYbhNzlqTtH4WfLCY5d2ycA/5GuNHB7Q4Jt38uNFxV2NBLMqzSty+RD33i+zs85NDuqcF",
+	"GZGAPHNjsOY6UKuRGIQ6VCQilVA8PUx/BH2tzY3uycoOGhryN4eLL0DJa4TSzMFYKIR/yDy3IWZ83qyT",
+	"88ZiDmIppBJTxfAhpg61n1zqV3hDs45S2jUxFoy+WhhVskA3HXG3xULoOSbZn67AW6GdpKHd5FL3+Nd2",
+	"l+VZ6i3qP9olQ4EoTe2xPIyhXsxH19SL+QgL6GlvVXPSTQJLFlxpnU8vqwn8l/QLEzy1cDlID474wR14",
+	"wx94MZ9c6nW1Z21fnrYP+Qvh4AaVYj60ULpBcyVuz+PLJycna9i5Ofc15GLpHsOrBFZCqe9n2dk/d0N4",
+	"Are7fB1skxG9EiPI52WFDUvhRrjO4jJHlHQeS7iRfgFSFyqUeJVarMPEEXXF0iDKbqr5JqsOdFrWTXA5",
+	"wqEfI49ek/HaNDK7cLhCO2dL6s0AjHVQCixWZokEvd07UiChVCNkWZ5RU9aF4US71RuC8Dqmbvm841ML",
+	"qWsosvfDfWB5ABruHaMHhvclbxvGVcF5mEZpLIK1qH0CKNbhHSD1XhCqA6S9MxgHKIu1EgW6BDsETzc9",
+	"IILBe55BBKL74MoW0jofbQxC3qKwxeJbeQ8sGQeShZwvlJwvRnCkIhUk95VdJ2PjJBk3+BWW4NFWbMIu",
+	"w8nJ06IS9pr/ip5WfHjcPU0IxF20A08u9QVxDW89Yf+3F9+9BHSFqFvEIqZSJ9H+UYNJ32fq+Y7jWBKZ",
+	"tc2RbxfqIKd6wPsNjzrPNN76qyJYZ+wmR+PzxuJRU6jFHPNk6cFEE66Eiy9G5zhAUCZ5i5m5aLVpBEcL",
+	"HwnckM60V0uWZdQcbHwzs6ba6uBMcWZshIBOv8d68WZrH2Lm0R7SxWDKu3k36IlnwCTkiTcDTuzl8Evp",
+	"donWmoFuPxt6P0aV6Hz0fLL8YHnsrfO+Ld6B8vImhQq2i81wQjcLA5W4Rre5RD1LwmhXSd3+3ruA62DM",
+	"iM/QQkYAaNE6dziHQugCFb9nkxAbNIYiv9QWFQqH8XkyJdw8eoepw2hNBk5y6oOEgsdojE6WZ6054q75",
+	"L+prxBjtkr/I2NGFSaGhg3E+bs83kV4oZW6wvOob2Z2imT6IXNm04EPR1bSh5UcDG77dCq4DZ4leSDXa",
+	"lENoV018Yd1IkY3CI3JReVPEjYEax82bNv6Kg0pjeCG187Sgo8N2Ts0BIQ0vvcIdEx15sZRGiXYdDtL2",
+	"fzSfjGr6uvXL13j15puv4M9/Ofkz1FGmIPLc5SCUM0DjCS+JiWyhG0HKLqIPtR7uCXrEadChmiKbON6w",
+	"ckfJKcoBbwusfXyT9r3eCrcY5egWl3dsn5Unasb050LM94DzQZwnHjwcXS/E/Du0c9ykYtx0sqeV9jOE",
+	"T93+7GC3spK6+bmpbLRH2s/bZBS58ZZZvcFmmcantcXIHzTullE7Hdgagtx0UCjIN861Q0ORsYtdscg7",
+	"ZuzMREq0FwVLHVaMa5mopUdR/bu7EfM52ok0WSPk2dv4DJ6/PocLFDT7YOmjhff12fFx75u7da1+Dk5U",
+	"tUL+2C+Eh+DQgWA188YiCN724m1s5g2UWBntvBUeYYbCB4utQn5fo6aenk5OYnRvJguRrJSSBWrHzEqE",
+	"P69FsUA4nZwMSHZnx8c3NzcTwa8nxs6P07fu+OX5V1+/evv10enkZLLwlWKpQFu572dv0S5lgWPzPuYm",
+	"x1mLtC3PXqdpZnm2ROsiU55MTiYn1LOpUYtaZmfZU36UZ7XwC5aWY2JQF0zZ1MIX/NxFvDIzkCVzyejk",
+	"44iCGnLsbcVbEwoxlG08ipBtcqnjviPuXRKPjS0jRsrSxaSInDU6noLDz1L+ICZE4n6HRJ2X4rxsqaPZ",
+	"86SsqNCjdewfDOdx/sK1gMyrTx/m0TzK0v3tSX6aP53Ai1ArWmuE8xeR3IYko4tkE2juMTeQ5Rne1oo1",
+	"jhMeJPzZWfYuoF11ki1Ll/UVKe5xI6oOwPeAjNcA4fZBnPMrFhPql72hIU9SsiWmZdjX4XSMo31um7aZ",
+	"gdCrLk3j8ks9ReevcDYz1rcfcworfR69ydpYn8KYMb1jdIrzj/EoZXw6ppQ4E7xXTWT2gyHNgx4hY77m",
+	"j8R0VxvtIjSenpw0uITRbjP1UbWPf3IRTjsS9iaWmqTS3QYgRfkgiUNRLFIakHgxpe9AOvI/CnQOS8Al",
+	"auJzZB4xi9bu2Xsktk2t3uWDXpIP9KeH9rYx7UookjVKVJpyRXLUU8q7vFvUj21iQeNtjQUBATvX+Uhe",
+	"M343H0vEvUmRd1IxhqB2P+FWjlSLbRb9Dg4tLMhaFSQe4M2lfiUqIEEpjC5lhdqHCtD5CXwnsEAtHHis",
+	"amPBibn0Xjpwopaoc9BYgF0YXQQHDqteA+mB1mUCz1EjB4RhbsVSlgJEmAfMQRQgRRGU5E8n8FWwYip9",
+	"sGBKaUAZSzJtrCaUxDl6QIWJOo1FTlslR8mdEhQWPjhCV+mgkuCDraXLoQ5qKbWwNBZaQ5POwUtdyDJo",
+	"D0thZXDwE+UWJ3CuYSEKWBARwjmEWgmPAkpZ+FARO84jTNJcRClr6QpaIKE9zaabu5LzoEQ783ohLHor",
+	"GiZSe6iMQuclgqxqtKUkTv1DLkUVJySUfBdEBaUUxBkrHLyjuS1RSQ/aaPDGemOJJXKGumxHn8BrK5Aj",
+	"XcIDall1BASrBSyNCr4WHpaoUQsiODKX/qlEsNTHue56nqFNXJ+JQirpBoPwCPRP3q1vAc6UQiEtbJkD",
+	"h2+t8DQx+n8Cb4OrUZeSuKwECU9pFAl9YbTDwpM08yxZVGjWOSxxIYugBEjt0ZahAiWnaM0EvjN2KgGD",
+	"dJUp+8tAr1mwlSiklmJyqd9yQLWqg4MZkugpMzWWm6Pp5MUGb0M1AdKMSnjfsV46lQOGga7EBQcVSApJ",
+	"NifweiEcKhXVokabPmcm8+Kih5kIhZyGyG7RjEPt+t8vUaWFk0u0VuTDoUlLgIo7GjXUcrqYwA8ealQK",
+	"tUf3LiDUxgW02KnQBIgVotEBUrmGk01PzbSYjzkT0gqFDroAb6XzNBdYSi9wAt8EVyCgZywog2x1QGMB",
+	"rkCFVjI5UXqbDyiP4oJg0SlC5YSGSsxpyqjSak3gP0P8tDJKyWb1METJ6UjJW+gBESjgk1om4YzTTqKR",
+	"IKbVRRIVWmCQOu9ISWqrpZMNwY5oKKQPpSRSnRMQfCNlaSHjSAOm8XgTeN1fGOZcorG26GWoergVhSbk",
+	"Pekm4B1zVb+RujzEUW3SuTOpPFqYrvI20zDlVO4V/9ziRtHX2ahvuTf4tM9Z1Ks2gdyFNeipmaXUkFBq",
+	"rEnKLkbKthDdTmrUAdSrvvfHv4RSo+HFzdjYrazIIrUxmVTWQzyOxG6hSslK+gFFe0Ngm8ObWpAUpaRH",
+	"HC+t5AJJoJbSBJcyINGH7tJD1MSJiuTZ+i1Uxp4HZO7lCRU5CXBIcugpuBnLf7xJmzKSudriTN7CZXZ0",
+	"mcHMWKAuyCbo+YQMBbk96kasXJetucbVFiIT/R2JtfAeLbX8n6N/+6Ms/5cafvbHvPfjs3/9Q3bA+mrm",
+	"jxc2iRtFwB2C1A45BLvELTTRf1dxlvfjHo/IsQ2p3f2Ga76634CyhDmnYSj7I7aJqyyv5tvFdUspwshQ",
+	"ir3OneOoXzwO7YC3oAkHSWP14EaDJue8hbSFcFcx8bzB3baEcJOWpswJ3wWhDl/QrurrHksZ68buORJ/",
+	"dL9xqFIsjrKNehxK/b0rycYG5VwBrluvTWMR60xGyeIuxg3Y+ymFuJ/R41IXiJWFmktlZh7ttnUyVl81",
+	"DUbUY7To8O6AQWP2eNeobYtfMKwoK6nTwuUw0NAmBtYtZVd71RSS5bTsSjo/SGmMIshGwdUOdf2lMZxD",
+	"E8gjiY2NIECNHhpisjxboCjZh/s5+zqlh9YVwho9B9Re+gRs57OjV0bj0XccBmJWSi75eHrybLeKZy+l",
+	"vt4cQ0l93URb22KK2LFF9bdLLsa4zHaVV+wa9L+PXuGtP/rq/RVzbB+NxiM2bIyijaeKcDnj4vEhC6Nv",
+	"7IBX4CFrspekRxOzim1q40YCVF+xg+FAgI51toOa3F5cPNXfxrT4xibneUl7nH1bnB80b0qvsTEHzbmM",
+	"HCx6K9Gt+b/UUugUVOR6s1WvPrZRyUsttfMouDCbC1di+CfWuNcUwvk7V55b6rH27Nael7R99KiL1cXF",
+	"y4YetiV20kWro1x1GNb77Ojv7Pd2y9Ur9zj9/PM95R4R4Hj2X5py9d6krKma3RSL1+gJMETZZmma1NEw",
+	"Q3H3AaPnW0gbAOzjiYGnAz4kgnFWf/34ZpVktFPMNQ0gGyY1ZTXmFh0H+589efrxzZPXTAk7T7utaL84",
+	"FEBTOj39+KY0slJCWRTlCoKj7b+xLUqmZc4em927y2Oq+9hx4Sr1vjNRQ22hLf6lEFYs8zUzeEfRm345",
+	"cA5W6OsY1bGocCl0gRPOyQ8qfNjwaOMh0oCU0b7gXqvoDRpbuhjLoEEbTeMkC+2dbhYY0+exoeybKlrU",
+	"aZDKt/vkWVDqiAuK43BjMclYxXtQVJLp7EJG0xW4WhS4bX/5bmfCu28h9xZE7oketDXUbbI1no0aDRA0",
+	"bccDjSmFvz9k8CiCiu8eHlH88cO6BoNa9XE3wY3p3ND//13slx4nSP8sy7tDipIEOKnnKp7ymgqHZcPB",
+	"8xfgQkzLp7MV1ES6kfKkeApHaJgiqbE3FksI2ksFdYjVh23lvUVisTQaarTSUN/P2+LqZggqAo7fx0/o",
+	"LXORXsbaaSLUtufTyCDHmNXOGqf9JU6pwqkrcGpUnOq9+iHcnei8P567qf8ju3YipAnxsDf4MbpOsxRm",
+	"KA06tt3RVFNsYVD7LT0XbUwRdRuqyB5f8GB3cUssXmn1sNXO8xd5461wi5aZC7HErtCld7B5NHX65eq8",
+	"vJcOzLCXKP3gKvDr7o4fEuoaSOyDI5GfwnS/SpiuOfu7FjqrS3Gw5YNAjeI1DFx6D3yiGP7IhyCe/uWL",
+	"zyaXujlU3kBdLFPk4dvizqSbiob2UXzIyHHNIlwj1oR8NFSz/FAYnU7KqNWYUWNC7mvTQp1yGR9Mod9/",
+	"HK49xr0uR3yy4Yj5/KcHd7khTN+0qfzIrM2T34abCgVNMf9vK+r3PnBtCGkhqYyxyR1pbkVYcNVYrJbf",
+	"hy6fvJfHgKlh1HtJx9fvs5loL75or86J+7e2rJfDiE2iIQfhoG5XIm+u7DAQdCy3EPPmHoAxsIygf1+0",
+	"TOfyPy643Ju2SLNi7f4EXp/A63cCXoPQyPHa8eWdu7LhaXSOYIvNo/aTbZuui8FR5sPQ5+Pccq1dZDCy",
+	"MON3Fjyycy3p2F2aXKd9eNtyZUsJQTRg/Qu4GlmjKK3oSWEOFgtjy+YKJvqET//Hy+lIYmWFHFzrPmIq",
+	"9p3HT+G3pvB4/bD/5FK3uNJsbiKettGHZmUJZ8aMcScm9zHIH92mZfMijJ0KAT7efvFbMclbLkv5Reb5",
+	"9xOC2IMC7e5/yF+QsU3Suw2zdZai60ToOIS8iQ0ciH7J4S5n/EXXLBb27A3ZD0t8uksDV5wDIPp7eYAx",
+	"AEhE3t8db8psHkk4suFRUo1PqvUw1UpK02ZKOqU5m7b3/+2s2WuuElk7k7/n2H2zS423E7dmmEmaNbcV",
+	"p4P4J/QoVTGNqUQs+9vrIP4uzps/zBofVKbc3oR5n1sARnfS7v+9AvDT+flP5+dHzs8T9jXXUO49Sd/d",
+	"xUiSsXbrkhfzOZ9u3lqGxfclYTm6872Ip4w+mPg3NzKNOdU0K4bpWN6Sbnf6VU8bfMrx/TohHVrrs6q9",
+	"FWvUzHPmLt2uGu/1jYt7s5ARCRtbHdNxQ1c9xavTNVrcB4l/iqLGK/ebOyl6l2xNgL5K8cV+sKPxJQqL",
+	"FeotFStMcatB73972t4ktk17aDPKDX5Ni8a3o40QFC9+g97NzI8zYpQOb7bMHwkdtSJvuyvTtmwE6b2L",
+	"os5hpCSk9xLMH1yS/Lj/oi7pL74HTDousvXjQZ44fsSxDyTBcYgtItwR/FsQ4UgJw8fjDXYS13eEOehY",
+	"FAsQv3IszXz1uF02m53BbXXNxXOT3vVtopZ0H/P/DQDN8/0USmcAAA==",
 }
 
 // GetSwagger returns the Swagger specification corresponding to the generated code
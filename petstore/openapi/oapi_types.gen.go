@@ -3,6 +3,36 @@
 // Code generated by github.com/deepmap/oapi-codegen DO NOT EDIT.
 package openapi
 
+import (
+	"time"
+
+	openapi_types "github.com/deepmap/oapi-codegen/pkg/types"
+)
+
+// BatchItemResult defines model for BatchItemResult.
+type BatchItemResult struct {
+
+	// RFC 7807 problem details, also compatible with Error
+	Error *Problem `json:"error,omitempty"`
+	Id    *int64   `json:"id,omitempty"`
+
+	// index of the item in the request
+	Index int  `json:"index"`
+	Pet   *Pet `json:"pet,omitempty"`
+
+	// HTTP status of the item, 201 created, 204 deleted, 400 invalid, 404 not found,
+	// or 424 not applied because another item failed in atomic mode
+	Status int `json:"status"`
+}
+
+// BatchResult defines model for BatchResult.
+type BatchResult struct {
+
+	// false if nothing is applied, because an item failed in atomic mode
+	Committed bool              `json:"committed"`
+	Results   []BatchItemResult `json:"results"`
+}
+
 // Error defines model for Error.
 type Error struct {
 	Code    int32  `json:"code"`
@@ -11,8 +41,31 @@ type Error struct {
 
 // NewPet defines model for NewPet.
 type NewPet struct {
-	Name string  `json:"name"`
-	Tag  *string `json:"tag,omitempty"`
+
+	// custom attributes, a JSON object of up to 50 keys and 4096 bytes
+	Attributes *map[string]interface{} `json:"attributes,omitempty"`
+
+	// date of birth, not in the future
+	BirthDate *openapi_types.Date `json:"birth_date,omitempty"`
+
+	// breed of the species
+	Breed *string `json:"breed,omitempty"`
+	Name  string  `json:"name"`
+
+	// sex of the pet
+	Sex *string `json:"sex,omitempty"`
+
+	// species like dog or cat
+	Species *string `json:"species,omitempty"`
+
+	// status in the store, available if absent.
+	// New pets are available or on_hold, then the status changes only by transitions.
+	Status *string `json:"status,omitempty"`
+	Tag    *string `json:"tag,omitempty"`
+
+	// tags of the pet, tag is the first of them. Without tags, it sets tags to the tag.
+	// v1 returns only tag, v2 returns tags as well
+	Tags *[]string `json:"tags,omitempty"`
 }
 
 // Pet defines model for Pet.
@@ -20,22 +73,271 @@ type Pet struct {
 	// Embedded struct due to allOf(#/components/schemas/NewPet)
 	NewPet `yaml:",inline"`
 	// Embedded fields due to inline allOf schema
-	Id int64 `json:"id"`
+
+	// time the pet was deleted, only listed with include_deleted
+	DeletedAt *time.Time `json:"deleted_at,omitempty"`
+	Id        int64      `json:"id"`
+}
+
+// PetPatch defines model for PetPatch.
+type PetPatch struct {
+
+	// merged into attributes, null removes an attribute or all of them
+	Attributes *map[string]interface{} `json:"attributes"`
+	BirthDate  *openapi_types.Date     `json:"birth_date"`
+	Breed      *string                 `json:"breed"`
+	Name       *string                 `json:"name,omitempty"`
+	Sex        *string                 `json:"sex"`
+	Species    *string                 `json:"species"`
+
+	// must be the current status, it changes only by transitions
+	Status *string `json:"status,omitempty"`
+	Tag    *string `json:"tag"`
+
+	// replaces tags, tag without tags replaces tags by the tag
+	Tags *[]string `json:"tags"`
+}
+
+// PetSearchHit defines model for PetSearchHit.
+type PetSearchHit struct {
+	// Embedded struct due to allOf(#/components/schemas/Pet)
+	Pet `yaml:",inline"`
+	// Embedded fields due to inline allOf schema
+
+	// matching name or tags with matched terms in <mark> and </mark>, only with highlight.
+	// The text is HTML escaped, only the marks are HTML.
+	Highlight *string `json:"highlight,omitempty"`
+}
+
+// PetSearchResult defines model for PetSearchResult.
+type PetSearchResult struct {
+	Items []PetSearchHit `json:"items"`
+
+	// cursor of the next page, absent on the last page
+	NextCursor *string `json:"next_cursor,omitempty"`
+}
+
+// PetTransition defines model for PetTransition.
+type PetTransition struct {
+	Actor     string    `json:"actor"`
+	CreatedAt time.Time `json:"created_at"`
+
+	// status before the transition
+	From string `json:"from"`
+
+	// status after the transition
+	To         string `json:"to"`
+	Transition string `json:"transition"`
+}
+
+// PetTransitionList defines model for PetTransitionList.
+type PetTransitionList struct {
+
+	// transitions of the pet, oldest first
+	Items []PetTransition `json:"items"`
+}
+
+// PetTransitionRequest defines model for PetTransitionRequest.
+type PetTransitionRequest struct {
+
+	// who makes the transition
+	Actor string `json:"actor"`
+
+	// reserve and hold from available, cancel and adopt from reserved,
+	// release from on_hold and return from adopted
+	Transition string `json:"transition"`
+}
+
+// Problem defines model for Problem.
+type Problem struct {
+	// Embedded struct due to allOf(#/components/schemas/Error)
+	Error `yaml:",inline"`
+	// Embedded fields due to inline allOf schema
+
+	// transitions allowed from the current status of the pet, on 409 of transitions
+	AllowedTransitions *[]string `json:"allowed_transitions,omitempty"`
+	Detail             *string   `json:"detail,omitempty"`
+
+	// machine-readable error code like not_found
+	ErrorCode  *string      `json:"error_code,omitempty"`
+	Instance   *string      `json:"instance,omitempty"`
+	Status     *int32       `json:"status,omitempty"`
+	Title      *string      `json:"title,omitempty"`
+	Type       *string      `json:"type,omitempty"`
+	Violations *[]Violation `json:"violations,omitempty"`
+}
+
+// Tag defines model for Tag.
+type Tag struct {
+
+	// number of pets with the tag, except pets in the trash
+	Count int    `json:"count"`
+	Name  string `json:"name"`
+}
+
+// TagList defines model for TagList.
+type TagList struct {
+	Items []Tag `json:"items"`
+}
+
+// TagMerge defines model for TagMerge.
+type TagMerge struct {
+
+	// tags merged and deleted
+	From []string `json:"from"`
+	Into string   `json:"into"`
+}
+
+// TagRename defines model for TagRename.
+type TagRename struct {
+	From string `json:"from"`
+	To   string `json:"to"`
+}
+
+// Violation defines model for Violation.
+type Violation struct {
+	Code    *string `json:"code,omitempty"`
+	Field   string  `json:"field"`
+	Message string  `json:"message"`
+}
+
+// DeletePetsParams defines parameters for DeletePets.
+type DeletePetsParams struct {
+
+	// IDs of pets to delete, like ids=1,2,3. Duplicate IDs are deleted once with one result
+	Ids []int64 `json:"ids"`
+
+	// atomic applies all items or nothing if any item fails,
+	// best_effort applies valid items and reports the failed ones
+	Mode *string `json:"mode,omitempty"`
 }
 
 // FindPetsParams defines parameters for FindPets.
 type FindPetsParams struct {
 
-	// tags to filter by
+	// tags to filter by, matched by tag_match
 	Tags *[]string `json:"tags,omitempty"`
 
+	// any returns pets with any of tags, all returns pets with all of tags
+	TagMatch *string `json:"tag_match,omitempty"`
+
 	// maximum number of results to return
 	Limit *int32 `json:"limit,omitempty"`
+
+	// opaque cursor returned by the previous page, valid only with the same sort
+	Cursor *string `json:"cursor,omitempty"`
+
+	// comma separated fields to order by, prefix "-" for descending. id is always the last key
+	Sort *string `json:"sort,omitempty"`
+
+	// name starts with, case insensitive
+	NamePrefix *string `json:"name_prefix,omitempty"`
+
+	// name contains, case insensitive
+	NameContains *string `json:"name_contains,omitempty"`
+
+	// id greater than
+	IdGt *int64 `json:"id_gt,omitempty"`
+
+	// id less than
+	IdLt *int64 `json:"id_lt,omitempty"`
+
+	// true returns pets with any tag, false returns pets without tags
+	HasTag *bool `json:"has_tag,omitempty"`
+
+	// species equals, case insensitive
+	Species *string `json:"species,omitempty"`
+
+	// breed equals, case insensitive
+	Breed *string `json:"breed,omitempty"`
+
+	// sex equals
+	Sex *string `json:"sex,omitempty"`
+
+	// statuses to filter by, pets with any of them
+	Status *[]string `json:"status,omitempty"`
+
+	// birth date on or after
+	BornAfter *openapi_types.Date `json:"born_after,omitempty"`
+
+	// birth date on or before
+	BornBefore *openapi_types.Date `json:"born_before,omitempty"`
+
+	// admin filter, true returns deleted pets with deleted_at as well, to list the trash
+	IncludeDeleted *bool `json:"include_deleted,omitempty"`
 }
 
 // AddPetJSONBody defines parameters for AddPet.
 type AddPetJSONBody NewPet
 
+// AddPetParams defines parameters for AddPet.
+type AddPetParams struct {
+
+	// Unique key of the request, retries with the same key and body replay the first response
+	// instead of creating another pet. Keys are kept for IdempotencyTTL of the server.
+	IdempotencyKey *string `json:"Idempotency-Key,omitempty"`
+}
+
+// SearchPetsParams defines parameters for SearchPets.
+type SearchPetsParams struct {
+
+	// terms separated by spaces
+	Q string `json:"q"`
+
+	// true returns highlight of each pet
+	Highlight *bool `json:"highlight,omitempty"`
+
+	// maximum number of results to return
+	Limit *int32 `json:"limit,omitempty"`
+
+	// opaque cursor returned by the previous page, valid only with the same q
+	Cursor *string `json:"cursor,omitempty"`
+}
+
+// PatchPetJSONBody defines parameters for PatchPet.
+type PatchPetJSONBody PetPatch
+
+// UpdatePetJSONBody defines parameters for UpdatePet.
+type UpdatePetJSONBody NewPet
+
+// TransitionPetJSONBody defines parameters for TransitionPet.
+type TransitionPetJSONBody PetTransitionRequest
+
+// AddPetsJSONBody defines parameters for AddPets.
+type AddPetsJSONBody []NewPet
+
+// AddPetsParams defines parameters for AddPets.
+type AddPetsParams struct {
+
+	// atomic applies all items or nothing if any item fails,
+	// best_effort applies valid items and reports the failed ones
+	Mode *string `json:"mode,omitempty"`
+}
+
+// MergeTagsJSONBody defines parameters for MergeTags.
+type MergeTagsJSONBody TagMerge
+
+// RenameTagJSONBody defines parameters for RenameTag.
+type RenameTagJSONBody TagRename
+
 // AddPetJSONRequestBody defines body for AddPet for application/json ContentType.
 type AddPetJSONRequestBody AddPetJSONBody
 
+// PatchPetJSONRequestBody defines body for PatchPet for application/json ContentType.
+type PatchPetJSONRequestBody PatchPetJSONBody
+
+// UpdatePetJSONRequestBody defines body for UpdatePet for application/json ContentType.
+type UpdatePetJSONRequestBody UpdatePetJSONBody
+
+// TransitionPetJSONRequestBody defines body for TransitionPet for application/json ContentType.
+type TransitionPetJSONRequestBody TransitionPetJSONBody
+
+// AddPetsJSONRequestBody defines body for AddPets for application/json ContentType.
+type AddPetsJSONRequestBody AddPetsJSONBody
+
+// MergeTagsJSONRequestBody defines body for MergeTags for application/json ContentType.
+type MergeTagsJSONRequestBody MergeTagsJSONBody
+
+// RenameTagJSONRequestBody defines body for RenameTag for application/json ContentType.
+type RenameTagJSONRequestBody RenameTagJSONBody
+
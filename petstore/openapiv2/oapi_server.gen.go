@@ -0,0 +1,1024 @@
+// Package openapiv2 provides primitives to interact with the openapi HTTP API.
+//
+// Code generated by github.com/deepmap/oapi-codegen DO NOT EDIT.
+package openapiv2
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/deepmap/oapi-codegen/pkg/runtime"
+	"github.com/go-chi/chi/v5"
+)
+
+// ServerInterface represents all server handlers.
+type ServerInterface interface {
+
+	// (POST /adoptions)
+	AdoptPet(w http.ResponseWriter, r *http.Request)
+
+	// (GET /adoptions/{id})
+	FindAdoptionById(w http.ResponseWriter, r *http.Request, id int64)
+
+	// (GET /owners)
+	FindOwners(w http.ResponseWriter, r *http.Request, params FindOwnersParams)
+
+	// (POST /owners)
+	AddOwner(w http.ResponseWriter, r *http.Request)
+
+	// (DELETE /owners/{id})
+	DeleteOwner(w http.ResponseWriter, r *http.Request, id int64)
+
+	// (GET /owners/{id})
+	FindOwnerById(w http.ResponseWriter, r *http.Request, id int64)
+
+	// (PUT /owners/{id})
+	UpdateOwner(w http.ResponseWriter, r *http.Request, id int64)
+
+	// (GET /owners/{id}/pets)
+	FindOwnerPets(w http.ResponseWriter, r *http.Request, id int64)
+
+	// (DELETE /pets)
+	DeletePets(w http.ResponseWriter, r *http.Request, params DeletePetsParams)
+
+	// (GET /pets)
+	FindPets(w http.ResponseWriter, r *http.Request, params FindPetsParams)
+
+	// (POST /pets)
+	AddPet(w http.ResponseWriter, r *http.Request, params AddPetParams)
+
+	// (GET /pets/search)
+	SearchPets(w http.ResponseWriter, r *http.Request, params SearchPetsParams)
+
+	// (DELETE /pets/{id})
+	DeletePet(w http.ResponseWriter, r *http.Request, id int64)
+
+	// (GET /pets/{id})
+	FindPetById(w http.ResponseWriter, r *http.Request, id int64)
+
+	// (PATCH /pets/{id})
+	PatchPet(w http.ResponseWriter, r *http.Request, id int64)
+
+	// (PUT /pets/{id})
+	UpdatePet(w http.ResponseWriter, r *http.Request, id int64)
+
+	// (GET /pets/{id}/transitions)
+	FindPetTransitions(w http.ResponseWriter, r *http.Request, id int64)
+
+	// (POST /pets/{id}/transitions)
+	TransitionPet(w http.ResponseWriter, r *http.Request, id int64)
+
+	// (POST /pets/{id}:restore)
+	RestorePet(w http.ResponseWriter, r *http.Request, id int64)
+
+	// (POST /pets:batch)
+	AddPets(w http.ResponseWriter, r *http.Request, params AddPetsParams)
+
+	// (GET /tags)
+	FindTags(w http.ResponseWriter, r *http.Request)
+
+	// (POST /tags:merge)
+	MergeTags(w http.ResponseWriter, r *http.Request)
+
+	// (POST /tags:rename)
+	RenameTag(w http.ResponseWriter, r *http.Request)
+}
+
+// ServerInterfaceWrapper converts contexts to parameters.
+type ServerInterfaceWrapper struct {
+	Handler            ServerInterface
+	HandlerMiddlewares []MiddlewareFunc
+}
+
+type MiddlewareFunc func(http.HandlerFunc) http.HandlerFunc
+
+// AdoptPet operation middleware
+func (siw *ServerInterfaceWrapper) AdoptPet(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var handler = func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.AdoptPet(w, r)
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler(w, r.WithContext(ctx))
+}
+
+// FindAdoptionById operation middleware
+func (siw *ServerInterfaceWrapper) FindAdoptionById(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id int64
+
+	err = runtime.BindStyledParameter("simple", false, "id", chi.URLParam(r, "id"), &id)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Invalid format for parameter id: %s", err), http.StatusBadRequest)
+		return
+	}
+
+	var handler = func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.FindAdoptionById(w, r, id)
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler(w, r.WithContext(ctx))
+}
+
+// FindOwners operation middleware
+func (siw *ServerInterfaceWrapper) FindOwners(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params FindOwnersParams
+
+	// ------------- Optional query parameter "limit" -------------
+	if paramValue := r.URL.Query().Get("limit"); paramValue != "" {
+
+	}
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", r.URL.Query(), &params.Limit)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Invalid format for parameter limit: %s", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "cursor" -------------
+	if paramValue := r.URL.Query().Get("cursor"); paramValue != "" {
+
+	}
+
+	err = runtime.BindQueryParameter("form", true, false, "cursor", r.URL.Query(), &params.Cursor)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Invalid format for parameter cursor: %s", err), http.StatusBadRequest)
+		return
+	}
+
+	var handler = func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.FindOwners(w, r, params)
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler(w, r.WithContext(ctx))
+}
+
+// AddOwner operation middleware
+func (siw *ServerInterfaceWrapper) AddOwner(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var handler = func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.AddOwner(w, r)
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler(w, r.WithContext(ctx))
+}
+
+// DeleteOwner operation middleware
+func (siw *ServerInterfaceWrapper) DeleteOwner(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id int64
+
+	err = runtime.BindStyledParameter("simple", false, "id", chi.URLParam(r, "id"), &id)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Invalid format for parameter id: %s", err), http.StatusBadRequest)
+		return
+	}
+
+	var handler = func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.DeleteOwner(w, r, id)
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler(w, r.WithContext(ctx))
+}
+
+// FindOwnerById operation middleware
+func (siw *ServerInterfaceWrapper) FindOwnerById(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id int64
+
+	err = runtime.BindStyledParameter("simple", false, "id", chi.URLParam(r, "id"), &id)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Invalid format for parameter id: %s", err), http.StatusBadRequest)
+		return
+	}
+
+	var handler = func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.FindOwnerById(w, r, id)
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler(w, r.WithContext(ctx))
+}
+
+// UpdateOwner operation middleware
+func (siw *ServerInterfaceWrapper) UpdateOwner(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id int64
+
+	err = runtime.BindStyledParameter("simple", false, "id", chi.URLParam(r, "id"), &id)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Invalid format for parameter id: %s", err), http.StatusBadRequest)
+		return
+	}
+
+	var handler = func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.UpdateOwner(w, r, id)
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler(w, r.WithContext(ctx))
+}
+
+// FindOwnerPets operation middleware
+func (siw *ServerInterfaceWrapper) FindOwnerPets(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id int64
+
+	err = runtime.BindStyledParameter("simple", false, "id", chi.URLParam(r, "id"), &id)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Invalid format for parameter id: %s", err), http.StatusBadRequest)
+		return
+	}
+
+	var handler = func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.FindOwnerPets(w, r, id)
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler(w, r.WithContext(ctx))
+}
+
+// DeletePets operation middleware
+func (siw *ServerInterfaceWrapper) DeletePets(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params DeletePetsParams
+
+	// ------------- Required query parameter "ids" -------------
+	if paramValue := r.URL.Query().Get("ids"); paramValue != "" {
+
+	} else {
+		http.Error(w, "Query argument ids is required, but not found", http.StatusBadRequest)
+		return
+	}
+
+	err = runtime.BindQueryParameter("form", false, true, "ids", r.URL.Query(), &params.Ids)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Invalid format for parameter ids: %s", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "mode" -------------
+	if paramValue := r.URL.Query().Get("mode"); paramValue != "" {
+
+	}
+
+	err = runtime.BindQueryParameter("form", true, false, "mode", r.URL.Query(), &params.Mode)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Invalid format for parameter mode: %s", err), http.StatusBadRequest)
+		return
+	}
+
+	var handler = func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.DeletePets(w, r, params)
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler(w, r.WithContext(ctx))
+}
+
+// FindPets operation middleware
+func (siw *ServerInterfaceWrapper) FindPets(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params FindPetsParams
+
+	// ------------- Optional query parameter "tags" -------------
+	if paramValue := r.URL.Query().Get("tags"); paramValue != "" {
+
+	}
+
+	err = runtime.BindQueryParameter("form", true, false, "tags", r.URL.Query(), &params.Tags)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Invalid format for parameter tags: %s", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "tag_match" -------------
+	if paramValue := r.URL.Query().Get("tag_match"); paramValue != "" {
+
+	}
+
+	err = runtime.BindQueryParameter("form", true, false, "tag_match", r.URL.Query(), &params.TagMatch)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Invalid format for parameter tag_match: %s", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "limit" -------------
+	if paramValue := r.URL.Query().Get("limit"); paramValue != "" {
+
+	}
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", r.URL.Query(), &params.Limit)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Invalid format for parameter limit: %s", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "cursor" -------------
+	if paramValue := r.URL.Query().Get("cursor"); paramValue != "" {
+
+	}
+
+	err = runtime.BindQueryParameter("form", true, false, "cursor", r.URL.Query(), &params.Cursor)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Invalid format for parameter cursor: %s", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "sort" -------------
+	if paramValue := r.URL.Query().Get("sort"); paramValue != "" {
+
+	}
+
+	err = runtime.BindQueryParameter("form", true, false, "sort", r.URL.Query(), &params.Sort)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Invalid format for parameter sort: %s", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "name_prefix" -------------
+	if paramValue := r.URL.Query().Get("name_prefix"); paramValue != "" {
+
+	}
+
+	err = runtime.BindQueryParameter("form", true, false, "name_prefix", r.URL.Query(), &params.NamePrefix)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Invalid format for parameter name_prefix: %s", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "name_contains" -------------
+	if paramValue := r.URL.Query().Get("name_contains"); paramValue != "" {
+
+	}
+
+	err = runtime.BindQueryParameter("form", true, false, "name_contains", r.URL.Query(), &params.NameContains)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Invalid format for parameter name_contains: %s", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "id_gt" -------------
+	if paramValue := r.URL.Query().Get("id_gt"); paramValue != "" {
+
+	}
+
+	err = runtime.BindQueryParameter("form", true, false, "id_gt", r.URL.Query(), &params.IdGt)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Invalid format for parameter id_gt: %s", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "id_lt" -------------
+	if paramValue := r.URL.Query().Get("id_lt"); paramValue != "" {
+
+	}
+
+	err = runtime.BindQueryParameter("form", true, false, "id_lt", r.URL.Query(), &params.IdLt)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Invalid format for parameter id_lt: %s", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "has_tag" -------------
+	if paramValue := r.URL.Query().Get("has_tag"); paramValue != "" {
+
+	}
+
+	err = runtime.BindQueryParameter("form", true, false, "has_tag", r.URL.Query(), &params.HasTag)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Invalid format for parameter has_tag: %s", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "species" -------------
+	if paramValue := r.URL.Query().Get("species"); paramValue != "" {
+
+	}
+
+	err = runtime.BindQueryParameter("form", true, false, "species", r.URL.Query(), &params.Species)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Invalid format for parameter species: %s", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "breed" -------------
+	if paramValue := r.URL.Query().Get("breed"); paramValue != "" {
+
+	}
+
+	err = runtime.BindQueryParameter("form", true, false, "breed", r.URL.Query(), &params.Breed)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Invalid format for parameter breed: %s", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "sex" -------------
+	if paramValue := r.URL.Query().Get("sex"); paramValue != "" {
+
+	}
+
+	err = runtime.BindQueryParameter("form", true, false, "sex", r.URL.Query(), &params.Sex)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Invalid format for parameter sex: %s", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "status" -------------
+	if paramValue := r.URL.Query().Get("status"); paramValue != "" {
+
+	}
+
+	err = runtime.BindQueryParameter("form", true, false, "status", r.URL.Query(), &params.Status)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Invalid format for parameter status: %s", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "born_after" -------------
+	if paramValue := r.URL.Query().Get("born_after"); paramValue != "" {
+
+	}
+
+	err = runtime.BindQueryParameter("form", true, false, "born_after", r.URL.Query(), &params.BornAfter)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Invalid format for parameter born_after: %s", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "born_before" -------------
+	if paramValue := r.URL.Query().Get("born_before"); paramValue != "" {
+
+	}
+
+	err = runtime.BindQueryParameter("form", true, false, "born_before", r.URL.Query(), &params.BornBefore)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Invalid format for parameter born_before: %s", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "include_deleted" -------------
+	if paramValue := r.URL.Query().Get("include_deleted"); paramValue != "" {
+
+	}
+
+	err = runtime.BindQueryParameter("form", true, false, "include_deleted", r.URL.Query(), &params.IncludeDeleted)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Invalid format for parameter include_deleted: %s", err), http.StatusBadRequest)
+		return
+	}
+
+	var handler = func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.FindPets(w, r, params)
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler(w, r.WithContext(ctx))
+}
+
+// AddPet operation middleware
+func (siw *ServerInterfaceWrapper) AddPet(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params AddPetParams
+
+	headers := r.Header
+
+	// ------------- Optional header parameter "Idempotency-Key" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("Idempotency-Key")]; found {
+		var IdempotencyKey string
+		n := len(valueList)
+		if n != 1 {
+			http.Error(w, fmt.Sprintf("Expected one value for Idempotency-Key, got %d", n), http.StatusBadRequest)
+			return
+		}
+
+		err = runtime.BindStyledParameter("simple", false, "Idempotency-Key", valueList[0], &IdempotencyKey)
+		if err != nil {
+			http.Error(w, fmt.Sprintf("Invalid format for parameter Idempotency-Key: %s", err), http.StatusBadRequest)
+			return
+		}
+
+		params.IdempotencyKey = &IdempotencyKey
+
+	}
+
+	var handler = func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.AddPet(w, r, params)
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler(w, r.WithContext(ctx))
+}
+
+// SearchPets operation middleware
+func (siw *ServerInterfaceWrapper) SearchPets(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params SearchPetsParams
+
+	// ------------- Required query parameter "q" -------------
+	if paramValue := r.URL.Query().Get("q"); paramValue != "" {
+
+	} else {
+		http.Error(w, "Query argument q is required, but not found", http.StatusBadRequest)
+		return
+	}
+
+	err = runtime.BindQueryParameter("form", true, true, "q", r.URL.Query(), &params.Q)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Invalid format for parameter q: %s", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "highlight" -------------
+	if paramValue := r.URL.Query().Get("highlight"); paramValue != "" {
+
+	}
+
+	err = runtime.BindQueryParameter("form", true, false, "highlight", r.URL.Query(), &params.Highlight)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Invalid format for parameter highlight: %s", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "limit" -------------
+	if paramValue := r.URL.Query().Get("limit"); paramValue != "" {
+
+	}
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", r.URL.Query(), &params.Limit)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Invalid format for parameter limit: %s", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "cursor" -------------
+	if paramValue := r.URL.Query().Get("cursor"); paramValue != "" {
+
+	}
+
+	err = runtime.BindQueryParameter("form", true, false, "cursor", r.URL.Query(), &params.Cursor)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Invalid format for parameter cursor: %s", err), http.StatusBadRequest)
+		return
+	}
+
+	var handler = func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.SearchPets(w, r, params)
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler(w, r.WithContext(ctx))
+}
+
+// DeletePet operation middleware
+func (siw *ServerInterfaceWrapper) DeletePet(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id int64
+
+	err = runtime.BindStyledParameter("simple", false, "id", chi.URLParam(r, "id"), &id)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Invalid format for parameter id: %s", err), http.StatusBadRequest)
+		return
+	}
+
+	var handler = func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.DeletePet(w, r, id)
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler(w, r.WithContext(ctx))
+}
+
+// FindPetById operation middleware
+func (siw *ServerInterfaceWrapper) FindPetById(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id int64
+
+	err = runtime.BindStyledParameter("simple", false, "id", chi.URLParam(r, "id"), &id)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Invalid format for parameter id: %s", err), http.StatusBadRequest)
+		return
+	}
+
+	var handler = func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.FindPetById(w, r, id)
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler(w, r.WithContext(ctx))
+}
+
+// PatchPet operation middleware
+func (siw *ServerInterfaceWrapper) PatchPet(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id int64
+
+	err = runtime.BindStyledParameter("simple", false, "id", chi.URLParam(r, "id"), &id)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Invalid format for parameter id: %s", err), http.StatusBadRequest)
+		return
+	}
+
+	var handler = func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PatchPet(w, r, id)
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler(w, r.WithContext(ctx))
+}
+
+// UpdatePet operation middleware
+func (siw *ServerInterfaceWrapper) UpdatePet(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id int64
+
+	err = runtime.BindStyledParameter("simple", false, "id", chi.URLParam(r, "id"), &id)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Invalid format for parameter id: %s", err), http.StatusBadRequest)
+		return
+	}
+
+	var handler = func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.UpdatePet(w, r, id)
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler(w, r.WithContext(ctx))
+}
+
+// FindPetTransitions operation middleware
+func (siw *ServerInterfaceWrapper) FindPetTransitions(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id int64
+
+	err = runtime.BindStyledParameter("simple", false, "id", chi.URLParam(r, "id"), &id)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Invalid format for parameter id: %s", err), http.StatusBadRequest)
+		return
+	}
+
+	var handler = func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.FindPetTransitions(w, r, id)
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler(w, r.WithContext(ctx))
+}
+
+// TransitionPet operation middleware
+func (siw *ServerInterfaceWrapper) TransitionPet(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id int64
+
+	err = runtime.BindStyledParameter("simple", false, "id", chi.URLParam(r, "id"), &id)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Invalid format for parameter id: %s", err), http.StatusBadRequest)
+		return
+	}
+
+	var handler = func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.TransitionPet(w, r, id)
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler(w, r.WithContext(ctx))
+}
+
+// RestorePet operation middleware
+func (siw *ServerInterfaceWrapper) RestorePet(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id int64
+
+	err = runtime.BindStyledParameter("simple", false, "id", chi.URLParam(r, "id"), &id)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Invalid format for parameter id: %s", err), http.StatusBadRequest)
+		return
+	}
+
+	var handler = func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.RestorePet(w, r, id)
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler(w, r.WithContext(ctx))
+}
+
+// AddPets operation middleware
+func (siw *ServerInterfaceWrapper) AddPets(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params AddPetsParams
+
+	// ------------- Optional query parameter "mode" -------------
+	if paramValue := r.URL.Query().Get("mode"); paramValue != "" {
+
+	}
+
+	err = runtime.BindQueryParameter("form", true, false, "mode", r.URL.Query(), &params.Mode)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Invalid format for parameter mode: %s", err), http.StatusBadRequest)
+		return
+	}
+
+	var handler = func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.AddPets(w, r, params)
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler(w, r.WithContext(ctx))
+}
+
+// FindTags operation middleware
+func (siw *ServerInterfaceWrapper) FindTags(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var handler = func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.FindTags(w, r)
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler(w, r.WithContext(ctx))
+}
+
+// MergeTags operation middleware
+func (siw *ServerInterfaceWrapper) MergeTags(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var handler = func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.MergeTags(w, r)
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler(w, r.WithContext(ctx))
+}
+
+// RenameTag operation middleware
+func (siw *ServerInterfaceWrapper) RenameTag(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var handler = func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.RenameTag(w, r)
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler(w, r.WithContext(ctx))
+}
+
+// Handler creates http.Handler with routing matching OpenAPI spec.
+func Handler(si ServerInterface) http.Handler {
+	return HandlerWithOptions(si, ChiServerOptions{})
+}
+
+type ChiServerOptions struct {
+	BaseURL     string
+	BaseRouter  chi.Router
+	Middlewares []MiddlewareFunc
+}
+
+// HandlerFromMux creates http.Handler with routing matching OpenAPI spec based on the provided mux.
+func HandlerFromMux(si ServerInterface, r chi.Router) http.Handler {
+	return HandlerWithOptions(si, ChiServerOptions{
+		BaseRouter: r,
+	})
+}
+
+func HandlerFromMuxWithBaseURL(si ServerInterface, r chi.Router, baseURL string) http.Handler {
+	return HandlerWithOptions(si, ChiServerOptions{
+		BaseURL:    baseURL,
+		BaseRouter: r,
+	})
+}
+
+// HandlerWithOptions creates http.Handler with additional options
+func HandlerWithOptions(si ServerInterface, options ChiServerOptions) http.Handler {
+	r := options.BaseRouter
+
+	if r == nil {
+		r = chi.NewRouter()
+	}
+	wrapper := ServerInterfaceWrapper{
+		Handler:            si,
+		HandlerMiddlewares: options.Middlewares,
+	}
+
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/adoptions", wrapper.AdoptPet)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/adoptions/{id}", wrapper.FindAdoptionById)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/owners", wrapper.FindOwners)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/owners", wrapper.AddOwner)
+	})
+	r.Group(func(r chi.Router) {
+		r.Delete(options.BaseURL+"/owners/{id}", wrapper.DeleteOwner)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/owners/{id}", wrapper.FindOwnerById)
+	})
+	r.Group(func(r chi.Router) {
+		r.Put(options.BaseURL+"/owners/{id}", wrapper.UpdateOwner)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/owners/{id}/pets", wrapper.FindOwnerPets)
+	})
+	r.Group(func(r chi.Router) {
+		r.Delete(options.BaseURL+"/pets", wrapper.DeletePets)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/pets", wrapper.FindPets)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/pets", wrapper.AddPet)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/pets/search", wrapper.SearchPets)
+	})
+	r.Group(func(r chi.Router) {
+		r.Delete(options.BaseURL+"/pets/{id}", wrapper.DeletePet)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/pets/{id}", wrapper.FindPetById)
+	})
+	r.Group(func(r chi.Router) {
+		r.Patch(options.BaseURL+"/pets/{id}", wrapper.PatchPet)
+	})
+	r.Group(func(r chi.Router) {
+		r.Put(options.BaseURL+"/pets/{id}", wrapper.UpdatePet)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/pets/{id}/transitions", wrapper.FindPetTransitions)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/pets/{id}/transitions", wrapper.TransitionPet)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/pets/{id}:restore", wrapper.RestorePet)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/pets:batch", wrapper.AddPets)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/tags", wrapper.FindTags)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/tags:merge", wrapper.MergeTags)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/tags:rename", wrapper.RenameTag)
+	})
+
+	return r
+}
+
@@ -0,0 +1,140 @@
+// Package openapiv2 provides primitives to interact with the openapi HTTP API.
+//
+// Code generated by github.com/deepmap/oapi-codegen DO NOT EDIT.
+package openapiv2
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/base64"
+	"fmt"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// Base64 encoded, gzipped, json marshaled Swagger object
+var swaggerSpec = []string{
+
+	"H4sIAAAAAAAC/+x9e3MbuZH4V+maX/5IfhlTsqzNQ1Wpu80+Krrs2j5bm7uqKKcCZ5ok1hhgDGAos/b8",
+	"3a+6gXmRGJLy2tq17H9scQaPRqNfaHT3/JQVpqqNRu1ddvFT5ooVVoL//LI0tZdG09+1NTVaL5HfiMIb",
+	"S3/4TY3ZRea8lXqZvc2zwqLwWN4IT68Xxlb0V1YKj4+8rDDLd/vIctRWav+H876d1B6XaKmhudVob45u",
+	"XqM/tvHbPLP4upEWy+zinwRR130wbR4XPlrmv7rRzPxHLDzN/Ffhi9Wlx+oFukb5XQSitQGBv7G4yC6y",
+	"/3fS78JJ3IKT59bMFVZ3QpHUJb6htiW6wsq4f+ExmAX4FYL0WIHU/DetGp2fwt9BEJHX67zwjdud9m9X",
+	"V88hvBxOnsPZ6WOIOKQf51CiQv5xfnoKUq+FkvzjHLTxsDCNLvNrbSycn4VHoq6VxBLmWIjGIQht/Apt",
+	"WNxCSIUlrVF4U8kCKlPitT5i4xl93YIm93ZqXwtTVdJ7LHdxsRDKIcgFQb+SegnStYvIB6vYs4Ae/Lkx",
+	"CoXOGHqChCennu7Qjm2T5ttuUGGt2OxgpF9RP1cKLd+0FL2NkBK3SffJWZLcKnROLDEhVnZAYly07VPQ",
+	"PMXbI6TXeINuVwYq8Qod06mI3XOwWBhbht2gN94K7SR3yrNKvPkO9dKvsovHp6d5Vknd/U6IunuTYNPC",
+	"awJdz6hdQlBVQqpdXDVavm4QCkEkrR0yQtY4RsjZF+cJFGhR8R7fEXP1yujtfk9O8wOkwpPlcRUTK3+O",
+	"CUYW3ls5bzwmpFrROG8q6JvkIOA/Xj57CmFcEnVNDd7AF6fwCjcOhC7h/PTPf4D5xqMbCqIekrm0fnVD",
+	"inJ3RnpKo3KbnOVfJMZF4xtLSxyp2pSWnVtMiSV+3ApnV2NBCBht4xene3Zx54VLqR/XK58aPW2Ibira",
+	"n0ooBh7jH41+pc2tHmzVYOQI3O7o4QUo+QqhNEswFgrhj1jFlN4Kz1scO28s5iDWQioxVyzExdyh9rNr",
+	"/RRvaU0OhMVBE2PB6JuVUWVOY7QD8bDFSuglOjBabWC+GQgUN7vWA+x0w2V5FkcLUhjtmgUyCyksk+jy",
+	"YpkgJKwtFkHtMvlI65hevVi6GfyX9CvTeP6Vg/TgaGX0i6iZOtCo6cnGKminRSXeXIaXzO17dQ4TV4pb",
+	"OyEllHq2yC7+uV/XdWLtbb6jlz6cnZqyJffai/9qV/addH53zwTUYsnsz7LcZfnWWjqkH2UAtBjZ2oI8",
+	"0/jG3xSNdSnlGJ63TExNGao8MgKYQOFKuPAiOySZA6ypTY7y+OgtDjbo9gZHgzJu8HgxtMGtMIJb4Xrr",
+	"k1lSSeexhFvpVyB1oZoSb2KLLE/TikVRPtNqk1142+D7pJ00uTxHf4hYSCjlsH4MFn1jdbBq5iylaMep",
+	"SdiDn0dOAfu/ZmJ6Thbv3TR8hXbJBp83IzWvG6XAYmXWSEq9fwfGglAqLqnK8oyasugeU8SUvt9W3xPd",
+	"E+p8S8cd7HhIcx+hmg/OMVDVdwVvSiVXjfMwD2xbNNYSnYS2rKj26NT3rFAPrqDVhWP4LdZKFOiibvVi",
+	"CbcDbQuj97yCTtserVQnQOuVbIo9XqKwxepv8g5CNy1xV3K5UnK5SkililiQzrxEfMQrvEgWsPwKS/Bo",
+	"K7a4rpvT0ydFJewr/gvZeA4PT/qnUVTzEN3Es2t9RVgjgSId/O3q++8AXSHqTrQTUmmQYK5Rg9nQGh+I",
+	"l7TQDciaOv3fWXL2uP91i9Cr/sj7gX2BC2uqSXt8jgtjcfcQvsuCZnIMsfBojxlitOT9uBuNxCtgEI52",
+	"F44w3Or1CdIaL6qf2Q0OWDkYVaLzwbzP8qPpcbDPh/xCR9LLi+hffDcnzM9xtYw3cFsYs8Rn0UJKAGjT",
+	"+tNbDoXQBSp+zyohNIjdyBNpUaFwGJ5HVcLNg7UVBwzaZHSmi2MQUfAcrdLJ8qxTRzw0/0VjJZTRPvqb",
+	"9vK0/uSj5Xzw6e1KeqGUucXyZqhk95Jm7BCwsqvBx6SryVXCj0Y6fFoLbgvOEn10Wu00Zb/7TeuU3FZS",
+	"pKPwEdnyfIbnxkCNg2tBG3/Dnujk4VA7TxuanLY3ao7wg3rpFe5ZaOLFWholun04itv/0XZJcvq29su3",
+	"cPXi26/gj386/SPUgaYg4NzlIJQzQPMJLwmJrKFbQsqugg217SNudMJo0E01R9seZcJA0SjKAd8UWPvw",
+	"pvfLulUSoxMmb9pXGKBJ8c+VWB4QzkdhnnDw7tL1Siy/p9PJLhRp1cmWVjzPkHzqD7JHm5WV1O3PXWaT",
+	"Oijb/biNSpEbT6zqBbbblF7WhJI/at6JWXsemLy32DVQJKoyjbVj7y/CEPsuMN4yYhcmQKK9KPzAGZ+J",
+	"WnoU1b+7W7Fcop1Jk7VEnr0Mz+DL55dwhYJW31jqtPK+vjg5GfR5u83VX4ITVa2QO/uV8NA4dCCYzbyx",
+	"CIKPvfgmNPMGSqyMdt4Kj7BA4RuLHUM+q1HTSE9mp8GzvJCFiFpKyQK1Y2RFwL+sRbFCOJudjkB2Fycn",
+	"t7e3M8GvZ8YuT2Jfd/Ld5VffPH35zaOz2els5SvFVIG2cs8WL9GuZYFxkNG6T7jJSdZJ2g5nz+Myszxb",
+	"o3UBKTT4KY1satSiltlF9oQf5Vkt/Iqp5aS9LeJftUl5Zvg+ipDZWhGEVTroCR0ce4Q2o6PRIwrqFg80",
+	"1HDrZmqgHHPWlyweE4qZnNWSj0Pa+KEFQ8Jgjsro3rkbwGi0lyr2CRYIluGURAzCG3hZtgt6zu78eIv8",
+	"V1NuWpLFINL5hjPs+smPLnBaEISHxOTwCo8ZYsvRFd8R7ISbbMhjdPwNd6O1IUqhuc5OH7832PYBFm39",
+	"7gIxy7MVihItQ/GdKUTaJiViag2hxBg9YNvCJYCwEPFI+l4W2IUevM1Ho0R1//t3HW0HW43GNzUWtFg2",
+	"twIxy84e3CFcOD87G743dkC6pcHQHN9I5691xE7Pnic/yfItgbvEBIu+iJ5SoTvMw1w4LMFoEOCkXiqE",
+	"y693WOFbqcuWJv66ueToEWFFhZ63/Z/bE11+TTs9JOEF+mLFGjKSQi/RZblD20NyOOxT/tcOJ5zeCyd0",
+	"62tnzx4apZ63lNgtdUyBLf3Fi5tDdBeagbElWgpt2YAsZ/AsPBUWQeMtaYn12SxJgs+6+6F9xFeJN7Jq",
+	"Kujt6xjXQXQYj5yREF83aDc9JSpZSZ9NER8fZyqpafDs4jR1ubENiqkFhxEEZ1arbFrvZ21xLU3jWgdW",
+	"CqTQda98/JDk31/bpeh/9+JuqAmkfrVLCkrqV6067rx6QblbVH+5Zq/gdbbPzzeNiTz770dP8Y1/9NX7",
+	"8yp+InoptElbdl+xtnadIZcDebFEsPdq9G4G35DlHnh4InRmlrCvAkN/OPsqDJ9YPb8gMhRlea+G1SRE",
+	"rUVkIkbe2aRqB/gU7Sk+PwbN5MaqqbOLgntgF5Vf8/Oexnu7iAa+/Bpc08Yy8mlkJRUODLOVcIEhwrHH",
+	"5fFine6Ednw4s2vdmhWdazAMQ/wTIGyv533qZBKAbZnnCEvMtPQel39fdtj5LqKjIRsW+XANppS9PqLV",
+	"nm6IPsL4h4z2Lco8ZLEzgRxvrndE8rHa6pPCNazs4VvpKaKLir1JUla8kj8o9HbI64e6FO8mgZo6RoB8",
+	"MOr6hUyJGODAcvsIm+IeyD6gujUJPl1Re8AsOGEBfOjsGmJgo4ofejWFLqMHJ5zu8vH5dloykzf2Dszz",
+	"EQrkNoovsaOMzqHt8+lJZSLDlvQOmaUtumTpEm50jnXesPVIoXtlF8wcrc0X0f9BDaIhyjQaRwwZSnLR",
+	"mZ4hnP88JvOE7KRpO/Q4OnbdRWdnhubh2lmW7i+P87P8yQy+bsImkfJxI2vY6Chaee3BoZPlGb6pFd9k",
+	"cfZR2oUiS7eXR7p7wiMSVkY3h4euDp3f8PULjZvtOodi5hMTJnIMQQhYBWP7HKoFCL3pc6Zcfq3n6PwN",
+	"LhbG+q4z55PF7iFKozbWh/uUmGtldMzMSOEopl/1SOlYMYI5DDJsHwwAScVwfEjJMkxSS/BjoA+iOBTF",
+	"KubkhfhgT78d1NYU6Bwx7Bp1uEQi5BGyaO/O36cYvC8xVAlFtEaqx5QboqMBUz4s+bqbZHjMEUqpIIK6",
+	"OB23ccRafBdMvxvXHuYLIg/w5lo/FRUQoRRGl7JC7ZsK0PkZfC+wQC0ceKxqY8GJpfReOnCilqhz0FiA",
+	"XRldNA4cVoMG0gPtywy+RI0caA1LK9ayFCCaZYM5iAKkKBoluesMvmqsmEvfWDClNKCMJZo2VpOUxCV6",
+	"QIUROo1Fzk7nxoEsQWHhG0fSVTqoJPjG1tLlUDdqLbWwNBdaQ4vOwUtdyLLRHtbCysbBj43zZgaXGlai",
+	"gBUBIZxDqJXwKKCUhW8qQsdlEJO0FlHKWrqCNkhoEvSiX7uSy0aJbuX1Slj0VrRIpPZQGYXOSwRZ1WhL",
+	"SZj6h1yLKixIKPm6ERWUUhBmrHDwmta2RiU9aL5xst5YQolcoC672Wfw3ApkX6/wgFpWPQCN1QLWRjW+",
+	"Fh7WqFELAjggd8l31I2lMS51P/ICbcT6QhRSSTeahGegf/J+fwtwphQKaWPLHPjUYIWnhdH/M3jZuBp1",
+	"KQnLShDxlEYR0RdGO+ICogJaJZMKrTqHNa5k0SgBpKxs2VSg5BytmcH3xs4lYCNdZcrhNtBrJmwlCqml",
+	"mF3rlxyoXNWNgwUS6SkzN5abo+npxTbeNtUMiDMq4X2PeulUDtiMeCVsOKiGqJBocwbPV8KhUoEtarSx",
+	"OyOZNxc9LERTyHkT0C3aeajdsP8aVdw4uUZrRT6emrgEZJl3bKjlfDWDHzzUqBRqj46c1bVxDVrsWWgG",
+	"hArR8gCxXIvJdqR2WYzHnAHpiEI3ugBvpfO0FlhLL3AG3zauQEDPsqBsZMcDJCdcgQqtZHAC9bYdKD/B",
+	"NYJJp2gqJzRUYklLRhV3awb/2YSulVFKtruHTaCcHpS8Ez0gmoJYJLSMxBmWHUkjipiOF4lUaINB6rwH",
+	"JbKtlk62ADuCoZCe3J9QCecENL6lsriRYaYR0ni+GTwfbgxjLsJYW/SyqQZyKxBNkw+omwRvylSlU9cx",
+	"hmqbC7iQypNDZJN3Efx0ayeWN/xzwoyi3lnStjwY1HnIWNSbLtGpDxekpzG5MWftlmgSs3YCZBNAd4tK",
+	"GoB6M7T++JdQKhm2e693se/1/jWPNnSfdkFNnKiInq1/9+vZHaAKU1UCHBIdegoapnA5Rkg4lBHN1RYX",
+	"8g1cZ4+uM1gYCzQE6QS9nJGiILNH3YqN6+8rX+FmAsgIfw9iLbxHSy3/59G//VaW/0sNf/fbfPDjd///",
+	"N9kR+6sZP17YSG55KmU+BRP9dxNWeTfs8YwcMyi1u9t0ba+7TShLWPLdmiUzcYpcZXmznCbXiVzIxFSK",
+	"rc6986ifPQ+dgCekCQcfh1IeOw3aXK4J0FbC3YSErh3sdvU8dmFpk9vxdSPU8RvaZ/LfYStDLYA7zsSd",
+	"7jYPlQMIs0xBj2Oqv3u5gMSkHIOP29prV1mE/M0kWDxEWoG9nxTDuyk9TiGFUBxCcwrqwqOdAH5urL5p",
+	"GyTYI1034ohJQ1bWvlm7Fj9jWlFWUseNy2HEoa0PrN/KPvkbhINbVCqnbVfS+VGqQFKC7GR872HXX847",
+	"3F/XjYIhvrlKlX1w3hq9BNRe+ijDLhePnhqNj75nj0+8TCe9+eT0fD83fxqRS09Sl/PaeKhMKRcc6DBG",
+	"YTCDHfAOvMuefA6mGgVTcbAjRwAPqrAMXOCx4kqIeM8hqoLWWqR3Fn/kSVORVSFufe9R54cQqPUKW7XQ",
+	"FkvLwaK3pJTHdjC1FDo6F/m6czMostLy67WW2nkUXHSHo5KCGyiUL6vJlfN3rhlkaURKPTQWLks6RnrU",
+	"xebq6rsWHtYpdtZ7rQPR9bJs0O3R39n+7fdyVKjpiwPplB/w5piTyXdp5jn6GIDWCpU2NeP+otEmQGtD",
+	"yUIpo3eORAvd97P8A3Gzx4J+vGRe1Z8/vlVF8u95fou5SHdKDbU1S4uO7xPOHz/5+NbJe6aEXcYDXdCb",
+	"7G2gJZ2dfXxLSuyUUBZFuYHGkYfB2E4Ax23OHpq+bW/TTxzXnDgunqOr20FeslChwyzgNTmIhpU8crBC",
+	"vwqOI4sK10IXOON0ulFgZ8hhMB4CDJxWdsWjVsEKNbZ0wV1Ck7acxvc4dDy7XWG4oQ8N5VAL0qbOG6l8",
+	"dxRfNEo94logYbqU2zMU4DjK8clw9l6p+QZcLQqcOsK+3nunPlS+B2sZHHBQdOVPuvvcoFmSPoi2bdqX",
+	"GaMEDnslHoTf8vWvM6dku8zMVHRQguc+Z5g8ECF9dDh+G+LMucQT0amDLGLpEhFQoYCW0DDnyCFvLJYx",
+	"C7huQuGArmiORUKxNBpqtNLQ2F+OM5ql49jC0D90obeMRXoZyp4QoHaY5BndYnvDqI6LBqzDyeGXD+Un",
+	"QAaB/OePP0bTaRHdG118XlDV5NMYlW2RnuNC5oi6c5FkD89psT9+JsTHpBIQ8tZa4RYdMldijX0szaBA",
+	"bvJ29vhchcgDH2umwsTB+2e7PUcU+84e0M/uwXtxD7ZlO7e8chy0f6Tmg4YahdrcXDUHuBgo/JbrFz35",
+	"0x9+N7vWbdHjVtSFSEievosfjbypaGofyCcG0Eu6WcaaJB9N1W4/FEbHIldqk1JqDMhdddrHmBzSVWDd",
+	"piMuSvSI8fz7dx5yh5i+7aIFArJ2i7aGzEKhoK3Dc3+pKPcl18YirYksY9q8QtcWw+TAtFDo5qAn8rP1",
+	"8gBk6v40t2NE6kSq210lWcwG++jy3PbfVvxiOW6fBctnwfIrcVucbFUF3XtiGhd5Ze+y2K1gO5s6EF0N",
+	"pjpW+nycx6Gt+sCJjUmXAn64aYPsXUmlcqfDCmJ9+MFnWFpa42zVUT297otPnb+Yi+ry5T798rJCdnz1",
+	"nRiKQ2Vuo2tsqlTf7Fp3cqU9eAR52nkG2p0lOZM6VfRkcheF/NEdKHbrS+9liDvUCLwXlTxRg/xnqedP",
+	"q6TNHikwSm0f0EAsJBj5bkdtXUTP93QBzxehgQMxjDjc53X/um8W4nkOutPHkT39p6M27J8n+Ac++pQA",
+	"iEDe3Rxvo2seiKuwxdFukM5n1roDa0Wm6W4xeqa5mHef1dkbx9dWd9qtbLsv655+UCBK+FJop4YZpEVf",
+	"HpXz8E/pUYwwStepPSqz6ZNIN383bXxUWfPuS1x3KQKQPEm7dwz8+5w+/zl9/oOmz5Psa7/udDCRvv/E",
+	"EVHG1scMPBUcL/Pd2nddiBR/hgDT5XGuQpLRByP/9kMHKaOaVjUo4BM/mnCvGQif79/ux6VDe31RdR+b",
+	"SKp5vlWLHy0Ln8sLm3u7kkEStro6XJWNTfUYmBK/TsFjhLh99qKGz1+3JSkG366YAfWK/sWhs6O1JQrL",
+	"aeppC5kh7jjo/R9Puw90THGPN2HF96rR+KMjCYDC91Rg8MHDh+kxirmbHfIn6k0xydv+SyQTB0F67wKp",
+	"sxspEumdCPMHFyk/nL9oSPqLP68hHQfA+rSTJ8wf5NgHouAwxQQJ9wD/Gkg4QMLi4+E6Ownre9wclCrF",
+	"BDQo4sdf9LTr9rAz+ghM+z2X2eCrKKKW9JnD/xsACoPi3RCEAAA=",
+}
+
+// GetSwagger returns the Swagger specification corresponding to the generated code
+// in this file.
+func GetSwagger() (*openapi3.Swagger, error) {
+	zipped, err := base64.StdEncoding.DecodeString(strings.Join(swaggerSpec, ""))
+	if err != nil {
+		return nil, fmt.Errorf("error base64 decoding spec: %s", err)
+	}
+	zr, err := gzip.NewReader(bytes.NewReader(zipped))
+	if err != nil {
+		return nil, fmt.Errorf("error decompressing spec: %s", err)
+	}
+	var buf bytes.Buffer
+	_, err = buf.ReadFrom(zr)
+	if err != nil {
+		return nil, fmt.Errorf("error decompressing spec: %s", err)
+	}
+
+	swagger, err := openapi3.NewSwaggerLoader().LoadSwaggerFromData(buf.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("error loading Swagger: %s", err)
+	}
+	return swagger, nil
+}
+
@@ -0,0 +1,49 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/opbls/scapo/petstore/domain"
+	"github.com/opbls/scapo/petstore/migration"
+)
+
+type (
+	// HealthRepository interface.
+	HealthRepository interface {
+		Ping(ctx context.Context) error
+	}
+
+	// HealthRepositoryImpl struct.
+	HealthRepositoryImpl struct {
+		DB       *sqlx.DB
+		Migrator migration.Migrator
+	}
+)
+
+// NewHealthRepository instantiate HealthRepository.
+func NewHealthRepository(db *sqlx.DB, migrator migration.Migrator) HealthRepository {
+	return &HealthRepositoryImpl{
+		DB:       db,
+		Migrator: migrator,
+	}
+}
+
+// Ping checks db is reachable and its schema is the latest.
+func (impl HealthRepositoryImpl) Ping(ctx context.Context) error {
+	if impl.DB == nil {
+		return domain.Err503ServiceUnavailable
+	}
+	if err := impl.DB.PingContext(ctx); err != nil {
+		return domain.Err503ServiceUnavailable.Wrap(err)
+	}
+
+	version, err := impl.Migrator.Version(ctx)
+	if err != nil {
+		return domain.Err503ServiceUnavailable.Wrap(err)
+	}
+	if version != impl.Migrator.Latest() {
+		return domain.Err503ServiceUnavailable
+	}
+	return nil
+}
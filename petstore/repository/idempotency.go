@@ -0,0 +1,129 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/opbls/scapo/petstore/domain"
+)
+
+type (
+	// IdempotencyRepository interface.
+	IdempotencyRepository interface {
+		// Reserve stores rec in progress, records created before expiredBefore
+		// and records still in progress reserved before leaseExpiredBefore are deleted first.
+		// It returns the existing record if the key is already stored, nil if reserved.
+		Reserve(ctx context.Context, rec *domain.IdempotencyRecord, expiredBefore time.Time, leaseExpiredBefore time.Time) (*domain.IdempotencyRecord, error)
+		// Complete stores the response of rec.
+		Complete(ctx context.Context, rec *domain.IdempotencyRecord) error
+		// Release deletes rec in progress, so that the key can be retried.
+		Release(ctx context.Context, scope string, key string) error
+	}
+
+	// IdempotencyRepositoryImpl struct.
+	IdempotencyRepositoryImpl struct {
+		DB *sqlx.DB
+	}
+
+	// idempotencyRow is a row of idempotency_keys.
+	idempotencyRow struct {
+		domain.IdempotencyRecord
+		Header sql.NullString `db:"header"`
+	}
+)
+
+// NewIdempotencyRepository instantiate IdempotencyRepository.
+func NewIdempotencyRepository(db *sqlx.DB) IdempotencyRepository {
+	return &IdempotencyRepositoryImpl{
+		DB: db,
+	}
+}
+
+// Reserve Impl.
+// The primary key makes concurrent requests with the same key reserve only one of them.
+func (impl IdempotencyRepositoryImpl) Reserve(ctx context.Context, rec *domain.IdempotencyRecord, expiredBefore time.Time, leaseExpiredBefore time.Time) (*domain.IdempotencyRecord, error) {
+	/*
+		DELETE FROM idempotency_keys WHERE created_at < '2006-01-02 15:04:05' OR (status IS NULL AND created_at < '2006-01-02 15:04:05');
+		INSERT INTO idempotency_keys(scope, key, request_hash, created_at) VALUES('POST /pets', 'abc', 'xyz', '2006-01-02 15:04:05')
+		ON CONFLICT(scope, key) DO NOTHING;
+		SELECT scope, key, request_hash, status, header, body, created_at FROM idempotency_keys WHERE scope = 'POST /pets' AND key = 'abc';
+	*/
+
+	var existing *domain.IdempotencyRecord
+	err := transaction(ctx, impl.DB, func(ctx context.Context) error {
+		db := conn(ctx, impl.DB)
+
+		_, err := db.ExecContext(ctx, `DELETE FROM idempotency_keys WHERE created_at < ? OR (status IS NULL AND created_at < ?)`,
+			expiredBefore.UTC(), leaseExpiredBefore.UTC())
+		if err != nil {
+			return dbError(ctx, err)
+		}
+
+		rslt, err := db.ExecContext(ctx, `INSERT INTO idempotency_keys(scope, key, request_hash, created_at) VALUES(?, ?, ?, ?)
+			ON CONFLICT(scope, key) DO NOTHING`, rec.Scope, rec.Key, rec.RequestHash, rec.CreatedAt.UTC())
+		if err != nil {
+			return dbError(ctx, err)
+		}
+		i, err := rslt.RowsAffected()
+		if err != nil {
+			return dbError(ctx, err)
+		}
+		if i > 0 {
+			return nil
+		}
+
+		row := idempotencyRow{}
+		err = db.GetContext(ctx, &row, `SELECT scope, key, request_hash, status, header, body, created_at
+			FROM idempotency_keys WHERE scope = ? AND key = ?`, rec.Scope, rec.Key)
+		if err != nil {
+			return dbError(ctx, err)
+		}
+		existing = &row.IdempotencyRecord
+		if row.Header.Valid {
+			if err := json.Unmarshal([]byte(row.Header.String), &existing.Header); err != nil {
+				return domain.Err500InternalServerError.Wrap(err)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return existing, nil
+}
+
+// Complete Impl.
+func (impl IdempotencyRepositoryImpl) Complete(ctx context.Context, rec *domain.IdempotencyRecord) error {
+	/*
+		UPDATE idempotency_keys SET status = 201, header = '{"Location":"/pets/1"}', body = '{...}' WHERE scope = 'POST /pets' AND key = 'abc'
+	*/
+
+	header, err := json.Marshal(rec.Header)
+	if err != nil {
+		return domain.Err500InternalServerError.Wrap(err)
+	}
+
+	SQL := `UPDATE idempotency_keys SET status = ?, header = ?, body = ? WHERE scope = ? AND key = ?`
+	_, err = conn(ctx, impl.DB).ExecContext(ctx, SQL, rec.Status, string(header), rec.Body, rec.Scope, rec.Key)
+	if err != nil {
+		return dbError(ctx, err)
+	}
+	return nil
+}
+
+// Release Impl.
+func (impl IdempotencyRepositoryImpl) Release(ctx context.Context, scope string, key string) error {
+	/*
+		DELETE FROM idempotency_keys WHERE scope = 'POST /pets' AND key = 'abc' AND status IS NULL
+	*/
+
+	SQL := `DELETE FROM idempotency_keys WHERE scope = ? AND key = ? AND status IS NULL`
+	_, err := conn(ctx, impl.DB).ExecContext(ctx, SQL, scope, key)
+	if err != nil {
+		return dbError(ctx, err)
+	}
+	return nil
+}
@@ -0,0 +1,323 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/mattn/go-sqlite3"
+	"github.com/opbls/scapo/petstore/domain"
+	"github.com/opbls/scapo/petstore/openapi"
+)
+
+type (
+	// OwnerRepository interface.
+	OwnerRepository interface {
+		// QueryOwners returns up to limit Owners with id greater than afterID, ordered by id.
+		QueryOwners(ctx context.Context, afterID int64, limit int) (*domain.Owners, error)
+		QueryOwner(ctx context.Context, id int) (*domain.Owner, error)
+		// CreateOwner and UpdateOwner return Err409Conflict if the email exists, also when it is taken concurrently.
+		CreateOwner(ctx context.Context, owner *domain.Owner) (*domain.Owner, error)
+		UpdateOwner(ctx context.Context, owner *domain.Owner) (int, error)
+		// DeleteOwner deletes adoptions of the owner with it.
+		DeleteOwner(ctx context.Context, id int) (int, error)
+		// QueryOwnerPets returns Pets adopted by the owner and not returned, ordered by id.
+		QueryOwnerPets(ctx context.Context, id int) (*domain.Pets, error)
+		// CountOwnerPets counts Pets adopted by the owner and not returned, including Pets in the trash.
+		CountOwnerPets(ctx context.Context, id int) (int, error)
+		QueryAdoption(ctx context.Context, id int) (*domain.Adoption, error)
+		// CreateAdoption records an adoption, the status of the pet is updated by PetStoreRepository.
+		CreateAdoption(ctx context.Context, adoption *domain.Adoption) (*domain.Adoption, error)
+	}
+
+	// OwnerRepositoryImpl struct.
+	OwnerRepositoryImpl struct {
+		DB *sqlx.DB
+	}
+)
+
+// errEmailExists is returned if another Owner has the email.
+var errEmailExists = domain.Err409Conflict.WithViolations(
+	domain.Violation{Field: "email", Code: "exists", Message: "is used by another owner"})
+
+// ownerColumns are selected into Owner.
+const ownerColumns = `id, name, email, phone, created_at`
+
+// NewOwnerRepository instantiate OwnerRepository.
+func NewOwnerRepository(db *sqlx.DB) OwnerRepository {
+	return &OwnerRepositoryImpl{
+		DB: db,
+	}
+}
+
+// QueryOwners return Owners from db.
+func (impl OwnerRepositoryImpl) QueryOwners(ctx context.Context, afterID int64, limit int) (*domain.Owners, error) {
+	/*
+		SELECT id, name, email, phone, created_at FROM owners WHERE id > 0 ORDER BY id LIMIT 10;
+	*/
+
+	SQL := `SELECT ` + ownerColumns + ` FROM owners WHERE id > ? ORDER BY id LIMIT ?`
+
+	// access db
+	rslts := domain.Owners{}
+	if err := conn(ctx, impl.DB).SelectContext(ctx, &rslts, SQL, afterID, limit); err != nil {
+		return nil, dbError(ctx, err)
+	}
+
+	return &rslts, nil
+}
+
+// QueryOwner return Owner from db.
+func (impl OwnerRepositoryImpl) QueryOwner(ctx context.Context, id int) (*domain.Owner, error) {
+	/*
+		SELECT id, name, email, phone, created_at FROM owners WHERE id = 1;
+	*/
+
+	SQL := `SELECT ` + ownerColumns + ` FROM owners WHERE id = ?`
+
+	// access db
+	rslt := domain.Owner{}
+	err := conn(ctx, impl.DB).GetContext(ctx, &rslt, SQL, id)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, dbError(ctx, err)
+	}
+
+	return &rslt, nil
+}
+
+// CreateOwner insert Owner into db.
+func (impl OwnerRepositoryImpl) CreateOwner(ctx context.Context, o *domain.Owner) (*domain.Owner, error) {
+	/*
+		SELECT COUNT(*) FROM owners WHERE email = 'foo@example.com' AND id <> 0;
+		INSERT INTO owners(name, email, phone, created_at) VALUES('foo', 'foo@example.com', NULL, '2006-01-02 15:04:05');
+	*/
+
+	SQL := `INSERT INTO owners(name, email, phone, created_at) VALUES(?, ?, ?, ?)`
+
+	err := transaction(ctx, impl.DB, func(ctx context.Context) error {
+		if err := impl.checkEmail(ctx, o); err != nil {
+			return err
+		}
+
+		// access db
+		o.CreatedAt = time.Now().UTC()
+		rslt, err := conn(ctx, impl.DB).ExecContext(ctx, SQL, o.Name, o.Email, o.Phone, o.CreatedAt)
+		if err != nil {
+			return emailError(ctx, err)
+		}
+		o.Id, err = rslt.LastInsertId()
+		if err != nil {
+			return dbError(ctx, err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return o, nil
+}
+
+// UpdateOwner replace Owner in db, created_at is kept.
+func (impl OwnerRepositoryImpl) UpdateOwner(ctx context.Context, o *domain.Owner) (int, error) {
+	/*
+		SELECT COUNT(*) FROM owners WHERE email = 'foo@example.com' AND id <> 1;
+		UPDATE owners SET name = 'foo', email = 'foo@example.com', phone = NULL WHERE id = 1;
+		SELECT id, name, email, phone, created_at FROM owners WHERE id = 1;
+	*/
+
+	notaffected := -1
+	SQL := `UPDATE owners SET name = ?, email = ?, phone = ? WHERE id = ?`
+
+	var i int64
+	err := transaction(ctx, impl.DB, func(ctx context.Context) error {
+		if err := impl.checkEmail(ctx, o); err != nil {
+			return err
+		}
+
+		// access db
+		rslt, err := conn(ctx, impl.DB).ExecContext(ctx, SQL, o.Name, o.Email, o.Phone, o.Id)
+		if err != nil {
+			return emailError(ctx, err)
+		}
+		i, err = rslt.RowsAffected()
+		if err != nil {
+			return dbError(ctx, err)
+		}
+		if i == 0 {
+			return nil
+		}
+		if err := conn(ctx, impl.DB).GetContext(ctx, &o.CreatedAt, `SELECT created_at FROM owners WHERE id = ?`, o.Id); err != nil {
+			return dbError(ctx, err)
+		}
+		return nil
+	})
+	if err != nil {
+		return notaffected, err
+	}
+
+	return int(i), nil
+}
+
+// DeleteOwner delete Owner and the Adoptions from db.
+func (impl OwnerRepositoryImpl) DeleteOwner(ctx context.Context, id int) (int, error) {
+	/*
+		DELETE FROM adoptions WHERE owner_id = 1;
+		DELETE FROM owners WHERE id = 1;
+	*/
+
+	notaffected := -1
+	SQL := `DELETE FROM owners WHERE id = ?`
+
+	var i int64
+	err := transaction(ctx, impl.DB, func(ctx context.Context) error {
+		db := conn(ctx, impl.DB)
+
+		// access db
+		if _, err := db.ExecContext(ctx, `DELETE FROM adoptions WHERE owner_id = ?`, id); err != nil {
+			return dbError(ctx, err)
+		}
+		rslt, err := db.ExecContext(ctx, SQL, id)
+		if err != nil {
+			return dbError(ctx, err)
+		}
+		i, err = rslt.RowsAffected()
+		if err != nil {
+			return dbError(ctx, err)
+		}
+		return nil
+	})
+	if err != nil {
+		return notaffected, err
+	}
+
+	return int(i), nil
+}
+
+// QueryOwnerPets return Pets of Owner from db.
+func (impl OwnerRepositoryImpl) QueryOwnerPets(ctx context.Context, id int) (*domain.Pets, error) {
+	/*
+		SELECT p.id, p.name, ... FROM petstore p JOIN adoptions a ON a.pet_id = p.id
+		WHERE a.owner_id = 1 AND p.status = 'adopted' AND p.deleted_at IS NULL
+		AND a.id = (SELECT MAX(id) FROM adoptions WHERE pet_id = p.id) ORDER BY p.id;
+	*/
+
+	SQL := `SELECT ` + petColumnsP + ` FROM petstore p JOIN adoptions a ON a.pet_id = p.id
+		WHERE a.owner_id = ? AND p.status = ? AND p.deleted_at IS NULL
+		AND a.id = (SELECT MAX(id) FROM adoptions WHERE pet_id = p.id) ORDER BY p.id`
+
+	// access db
+	rows := []petRow{}
+	if err := conn(ctx, impl.DB).SelectContext(ctx, &rows, SQL, id, domain.PetStatusAdopted); err != nil {
+		return nil, dbError(ctx, err)
+	}
+	rslts := domain.Pets{}
+	for _, row := range rows {
+		p, err := row.versionedPet()
+		if err != nil {
+			return nil, err
+		}
+		rslts = append(rslts, openapi.Pet(p.Pet))
+	}
+
+	pets := []*domain.Pet{}
+	for i := range rslts {
+		pets = append(pets, (*domain.Pet)(&rslts[i]))
+	}
+	if err := loadPetTags(ctx, impl.DB, pets...); err != nil {
+		return nil, err
+	}
+
+	return &rslts, nil
+}
+
+// CountOwnerPets return the number of Pets of Owner in db, including Pets in the trash.
+func (impl OwnerRepositoryImpl) CountOwnerPets(ctx context.Context, id int) (int, error) {
+	/*
+		SELECT COUNT(*) FROM petstore p JOIN adoptions a ON a.pet_id = p.id
+		WHERE a.owner_id = 1 AND p.status = 'adopted'
+		AND a.id = (SELECT MAX(id) FROM adoptions WHERE pet_id = p.id);
+	*/
+
+	SQL := `SELECT COUNT(*) FROM petstore p JOIN adoptions a ON a.pet_id = p.id
+		WHERE a.owner_id = ? AND p.status = ?
+		AND a.id = (SELECT MAX(id) FROM adoptions WHERE pet_id = p.id)`
+
+	// access db
+	var n int
+	if err := conn(ctx, impl.DB).GetContext(ctx, &n, SQL, id, domain.PetStatusAdopted); err != nil {
+		return -1, dbError(ctx, err)
+	}
+
+	return n, nil
+}
+
+// QueryAdoption return Adoption from db.
+func (impl OwnerRepositoryImpl) QueryAdoption(ctx context.Context, id int) (*domain.Adoption, error) {
+	/*
+		SELECT id, pet_id, owner_id, actor, created_at FROM adoptions WHERE id = 1;
+	*/
+
+	SQL := `SELECT id, pet_id, owner_id, actor, created_at FROM adoptions WHERE id = ?`
+
+	// access db
+	rslt := domain.Adoption{}
+	err := conn(ctx, impl.DB).GetContext(ctx, &rslt, SQL, id)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, dbError(ctx, err)
+	}
+
+	return &rslt, nil
+}
+
+// CreateAdoption insert Adoption into db.
+func (impl OwnerRepositoryImpl) CreateAdoption(ctx context.Context, a *domain.Adoption) (*domain.Adoption, error) {
+	/*
+		INSERT INTO adoptions(pet_id, owner_id, actor, created_at) VALUES(1, 1, 'alice', '2006-01-02 15:04:05');
+	*/
+
+	SQL := `INSERT INTO adoptions(pet_id, owner_id, actor, created_at) VALUES(?, ?, ?, ?)`
+
+	// access db
+	a.CreatedAt = time.Now().UTC()
+	rslt, err := conn(ctx, impl.DB).ExecContext(ctx, SQL, a.PetId, a.OwnerId, a.Actor, a.CreatedAt)
+	if err != nil {
+		return nil, dbError(ctx, err)
+	}
+	a.Id, err = rslt.LastInsertId()
+	if err != nil {
+		return nil, dbError(ctx, err)
+	}
+
+	return a, nil
+}
+
+// checkEmail returns Err409Conflict if another Owner has the email of o.
+func (impl OwnerRepositoryImpl) checkEmail(ctx context.Context, o *domain.Owner) error {
+	var n int
+	if err := conn(ctx, impl.DB).GetContext(ctx, &n, `SELECT COUNT(*) FROM owners WHERE email = ? AND id <> ?`, o.Email, o.Id); err != nil {
+		return dbError(ctx, err)
+	}
+	if n > 0 {
+		return errEmailExists
+	}
+	return nil
+}
+
+// emailError returns errEmailExists for the unique constraint of owners.email,
+// which is hit if another Owner takes the email after checkEmail.
+func emailError(ctx context.Context, err error) error {
+	var sqliteErr sqlite3.Error
+	if errors.As(err, &sqliteErr) && sqliteErr.ExtendedCode == sqlite3.ErrConstraintUnique {
+		return errEmailExists.Wrap(err)
+	}
+	return dbError(ctx, err)
+}
@@ -0,0 +1,129 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	// sqlite driver
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/opbls/scapo/petstore/domain"
+	"github.com/opbls/scapo/petstore/migration"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOwners(t *testing.T) {
+	ctx := context.Background()
+
+	db, _ := sqlx.Connect("sqlite3", ":memory:")
+	defer db.Close()
+	db.SetMaxOpenConns(1)
+
+	migrator, _ := migration.NewMigrator(db)
+	if _, err := migrator.Up(ctx); err != nil {
+		t.Fatal(err)
+	}
+	pets := NewPetStoreRepository(db)
+	repo := NewOwnerRepository(db)
+
+	//////////////////
+	// TEST DATA
+	//////////////////
+	for _, status := range []string{domain.PetStatusAdopted, domain.PetStatusAdopted, domain.PetStatusAvailable} {
+		s := status
+		p := domain.Pet{}
+		p.Name = "foo"
+		p.Status = &s
+		if _, err := pets.CreatePet(ctx, &p); err != nil {
+			t.Fatal(err)
+		}
+	}
+	for _, email := range []string{"alice@example.com", "bob@example.com"} {
+		if _, err := repo.CreateOwner(ctx, &domain.Owner{Name: "foo", Email: email}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// pet 2 moved from alice to bob, pet 3 was returned by alice
+	for _, a := range []domain.Adoption{{PetId: 1, OwnerId: 1}, {PetId: 2, OwnerId: 1}, {PetId: 2, OwnerId: 2}, {PetId: 3, OwnerId: 1}} {
+		a.Actor = "carol"
+		if _, err := repo.CreateAdoption(ctx, &a); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	////////////////////
+	// TEST
+	////////////////////
+	t.Run("SUCCESS_QueryOwners", func(t *testing.T) {
+		rslt, err := repo.QueryOwners(ctx, 0, 1)
+		if assert.NoError(t, err) && assert.Len(t, *rslt, 1) {
+			assert.Equal(t, "alice@example.com", (*rslt)[0].Email)
+		}
+		rslt, err = repo.QueryOwners(ctx, 1, 10)
+		if assert.NoError(t, err) && assert.Len(t, *rslt, 1) {
+			assert.Equal(t, int64(2), (*rslt)[0].Id)
+		}
+	})
+
+	t.Run("SUCCESS_QueryOwnerPets", func(t *testing.T) {
+		rslt, err := repo.QueryOwnerPets(ctx, 1)
+		if assert.NoError(t, err) && assert.Len(t, *rslt, 1) {
+			assert.Equal(t, int64(1), (*rslt)[0].Id)
+		}
+		rslt, err = repo.QueryOwnerPets(ctx, 2)
+		if assert.NoError(t, err) && assert.Len(t, *rslt, 1) {
+			assert.Equal(t, int64(2), (*rslt)[0].Id)
+		}
+	})
+
+	t.Run("SUCCESS_CountOwnerPets", func(t *testing.T) {
+		// pets in the trash are counted
+		pets.DeletePet(ctx, 1, 0)
+		n, err := repo.CountOwnerPets(ctx, 1)
+		assert.NoError(t, err)
+		assert.Equal(t, 1, n)
+		rslt, err := repo.QueryOwnerPets(ctx, 1)
+		assert.NoError(t, err)
+		assert.Empty(t, *rslt)
+	})
+
+	t.Run("ABNORMAL_CreateOwner_Email_Exists", func(t *testing.T) {
+		_, err := repo.CreateOwner(ctx, &domain.Owner{Name: "foo", Email: "ALICE@example.com"})
+		assert.True(t, errors.Is(err, domain.Err409Conflict))
+
+		i, err := repo.UpdateOwner(ctx, &domain.Owner{Id: 2, Name: "foo", Email: "alice@EXAMPLE.com"})
+		assert.True(t, errors.Is(err, domain.Err409Conflict))
+		assert.Equal(t, -1, i)
+	})
+
+	t.Run("ABNORMAL_CreateOwner_Unique_Constraint", func(t *testing.T) {
+		// another owner takes the email after checkEmail
+		_, err := db.ExecContext(ctx, `INSERT INTO owners(name, email, created_at) VALUES('foo', 'Bob@example.com', ?)`, time.Now())
+		err = emailError(ctx, err)
+		assert.True(t, errors.Is(err, domain.Err409Conflict))
+		if e, ok := err.(*domain.Error); assert.True(t, ok) && assert.Len(t, e.Violations, 1) {
+			assert.Equal(t, "email", e.Violations[0].Field)
+		}
+	})
+
+	t.Run("SUCCESS_QueryOwner_NotFound", func(t *testing.T) {
+		rslt, err := repo.QueryOwner(ctx, 100)
+		assert.NoError(t, err)
+		assert.Nil(t, rslt)
+	})
+
+	t.Run("SUCCESS_PurgePets_Adoptions", func(t *testing.T) {
+		pets.DeletePet(ctx, 2, 0)
+		_, err := pets.PurgePets(ctx, time.Now().Add(time.Second))
+		assert.NoError(t, err)
+
+		var n int
+		assert.NoError(t, db.GetContext(ctx, &n, `SELECT COUNT(*) FROM adoptions WHERE pet_id = 2`))
+		assert.Equal(t, 0, n)
+		rslt, err := repo.QueryOwnerPets(ctx, 2)
+		assert.NoError(t, err)
+		assert.Empty(t, *rslt)
+	})
+}
@@ -1,20 +1,48 @@
 package repository
 
 import (
-	"bytes"
-	"encoding/json"
+	"context"
+	"database/sql"
+	"errors"
+	"time"
 
 	"github.com/jmoiron/sqlx"
 	"github.com/opbls/scapo/petstore/domain"
+	"github.com/opbls/scapo/petstore/openapi"
 )
 
 type (
 	// PetStoreRepository interface.
 	PetStoreRepository interface {
-		QueryPets(condition *domain.QueryCondition) (*domain.Pets, error)
-		QueryPet(id int) (*domain.Pet, error)
-		CreatePet(pet *domain.Pet) (*domain.Pet, error)
-		DeletePet(id int) (int, error)
+		QueryPets(ctx context.Context, query *domain.PetQuery) (*domain.Pets, error)
+		QueryPet(ctx context.Context, id int) (*domain.VersionedPet, error)
+		CreatePet(ctx context.Context, pet *domain.Pet) (*domain.Pet, error)
+		// UpdatePet updates pet of pet.Version, any version if 0, and sets the new version to pet.Version.
+		// Err412PreconditionFailed is returned if the version does not match.
+		UpdatePet(ctx context.Context, pet *domain.VersionedPet) (int, error)
+		// DeletePet moves pet of version to the trash, any version if 0.
+		// Err412PreconditionFailed is returned if the version does not match.
+		DeletePet(ctx context.Context, id int, version int64) (int, error)
+		// RestorePet restores pet from the trash, nil if not deleted.
+		RestorePet(ctx context.Context, id int) (*domain.VersionedPet, error)
+		// PurgePets deletes pets in the trash since before deletedBefore.
+		PurgePets(ctx context.Context, deletedBefore time.Time) (int, error)
+		// SearchPets returns Pets matching all terms of search by name or tags, except Pets in the trash.
+		// Pets are ranked by the index of SetupSearch, or matched by LIKE without it.
+		SearchPets(ctx context.Context, search *domain.PetSearch) (*domain.PetSearchHits, error)
+
+		// QueryPetTransitions returns transitions of pet, oldest first.
+		QueryPetTransitions(ctx context.Context, id int) (*domain.PetTransitions, error)
+		// CreatePetTransition records a transition of pet, the status is updated by UpdatePet.
+		CreatePetTransition(ctx context.Context, id int, t *domain.PetTransition) error
+
+		// QueryTags returns all tags ordered by name.
+		QueryTags(ctx context.Context) (*domain.Tags, error)
+		// RenameTag renames tag from to to, nil if from does not exist.
+		// Err409Conflict is returned if to exists.
+		RenameTag(ctx context.Context, from string, to string) (*domain.Tag, error)
+		// MergeTags replaces tags of from by into and deletes them, nil if any of from does not exist.
+		MergeTags(ctx context.Context, from []string, into string) (*domain.Tag, error)
 	}
 
 	// PetStoreRepositoryImpl struct.
@@ -31,128 +59,328 @@ func NewPetStoreRepository(db *sqlx.DB) PetStoreRepository {
 }
 
 // QueryPets return Pets from db.
-func (impl PetStoreRepositoryImpl) QueryPets(condition *domain.QueryCondition) (*domain.Pets, error) {
+func (impl PetStoreRepositoryImpl) QueryPets(ctx context.Context, query *domain.PetQuery) (*domain.Pets, error) {
 	/*
-		SELECT id, name, tag FROM petstore WHERE tag IN ('foo', 'bar') LIMIT 10;
+		SELECT id, name, tag FROM petstore
+		WHERE tag IN ('foo', 'bar') AND name LIKE 'a%' ESCAPE '\' AND ((name > 'a') OR (name = 'a' AND id < 10))
+		ORDER BY name ASC, id DESC LIMIT 10;
 	*/
 
 	// build sql
-	SQL := `SELECT id, name, tag FROM petstore `
-	if _, ok := (*condition)["tags"]; ok {
-		SQL += `WHERE tag IN (:tags) `
+	SQL, args, err := compileQueryPets(query)
+	if err != nil {
+		return nil, err
 	}
-	SQL += `LIMIT :limit`
+	db := conn(ctx, impl.DB)
+	SQL = db.Rebind(SQL)
 
-	// build bind parameter
-	query, binds, err := sqlx.Named(SQL, asMap(condition))
+	// access db
+	rows := []petRow{}
+	err = db.SelectContext(ctx, &rows, SQL, args...)
 	if err != nil {
-		return nil, domain.Err500InternalServerError
+		return nil, dbError(ctx, err)
 	}
-	query, binds, err = sqlx.In(query, binds...)
-	if err != nil {
-		return nil, domain.Err500InternalServerError
+	rslts := domain.Pets{}
+	for _, row := range rows {
+		p, err := row.versionedPet()
+		if err != nil {
+			return nil, err
+		}
+		rslts = append(rslts, openapi.Pet(p.Pet))
 	}
-	query = impl.DB.Rebind(query)
 
-	// access db
-	rslts := domain.Pets{}
-	err = impl.DB.Select(&rslts, query, binds...)
-	if err != nil {
-		return nil, domain.Err500InternalServerError
+	pets := []*domain.Pet{}
+	for i := range rslts {
+		pets = append(pets, (*domain.Pet)(&rslts[i]))
+	}
+	if err := loadPetTags(ctx, impl.DB, pets...); err != nil {
+		return nil, err
 	}
 
 	return &rslts, nil
 }
 
 // QueryPet return Pet from db.
-func (impl PetStoreRepositoryImpl) QueryPet(id int) (*domain.Pet, error) {
+func (impl PetStoreRepositoryImpl) QueryPet(ctx context.Context, id int) (*domain.VersionedPet, error) {
 	/*
-		SELECT id, name, tag FROM petstore WHERE id = 1 LIMIT 1;
+		SELECT id, name, species, breed, birth_date, sex, status, attributes, version FROM petstore WHERE id = 1 AND deleted_at IS NULL LIMIT 1;
+		SELECT pt.pet_id, t.name FROM pet_tags pt JOIN tags t ON t.id = pt.tag_id WHERE pt.pet_id IN (1) ORDER BY pt.pet_id, pt.position;
 	*/
 
 	// build sql
-	SQL := `SELECT id, name, tag FROM petstore WHERE id = :id LIMIT 1`
+	SQL := `SELECT ` + petColumns + `, version FROM petstore WHERE id = :id AND deleted_at IS NULL LIMIT 1`
 
 	// access db
-	rows, err := impl.DB.Queryx(SQL, id)
+	row := petRow{}
+	err := conn(ctx, impl.DB).GetContext(ctx, &row, SQL, id)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
 	if err != nil {
-		return nil, domain.Err500InternalServerError
+		return nil, dbError(ctx, err)
 	}
-	defer rows.Close()
-
-	if rows.Next() {
-		rslt := domain.Pet{}
-		rows.StructScan(&rslt)
-		return &rslt, nil
+	rslt, err := row.versionedPet()
+	if err != nil {
+		return nil, err
+	}
+	if err := loadPetTags(ctx, impl.DB, &rslt.Pet); err != nil {
+		return nil, err
 	}
 
-	return nil, nil
+	return &rslt, nil
 }
 
 // CreatePet provide Pet to db.
-func (impl PetStoreRepositoryImpl) CreatePet(p *domain.Pet) (*domain.Pet, error) {
+func (impl PetStoreRepositoryImpl) CreatePet(ctx context.Context, p *domain.Pet) (*domain.Pet, error) {
 	/*
-		INSERT INTO petstore(name, tag) VALUES('foo', 'bar');
+		INSERT INTO petstore(name, species, breed, birth_date, sex, status, attributes)
+		VALUES('foo', 'dog', NULL, '2020-01-02', 'male', 'available', '{"color":"brown"}');
+		INSERT INTO pet_tags(pet_id, tag_id, position) SELECT 1, id, 0 FROM tags WHERE name = 'bar';
 	*/
 
-	SQL := `INSERT INTO petstore(name, tag) VALUES(:name, :tag)`
+	SQL := `INSERT INTO petstore(name, species, breed, birth_date, sex, status, attributes)
+		VALUES(:name, :species, :breed, :birth_date, :sex, :status, :attributes)`
 
-	// access db
-	stmt, err := impl.DB.Preparex(SQL)
+	args, err := petArgs(p)
 	if err != nil {
-		return nil, domain.Err500InternalServerError
+		return nil, err
 	}
-	defer stmt.Close()
+	err = transaction(ctx, impl.DB, func(ctx context.Context) error {
+		// access db
+		rslt, err := conn(ctx, impl.DB).NamedExecContext(ctx, SQL, args)
+		if err != nil {
+			return dbError(ctx, err)
+		}
+		i, err := rslt.LastInsertId()
+		if err != nil {
+			return dbError(ctx, err)
+		}
 
-	rslt, err := stmt.Exec(p.Name, p.Tag)
+		p.Id = i
+		return impl.setPetTags(ctx, p.Id, p.Tags)
+	})
 	if err != nil {
-		return nil, domain.Err500InternalServerError
+		return nil, err
 	}
-	i, err := rslt.LastInsertId()
+
+	return p, nil
+}
+
+// UpdatePet replace Pet in db.
+func (impl PetStoreRepositoryImpl) UpdatePet(ctx context.Context, p *domain.VersionedPet) (int, error) {
+	/*
+		UPDATE petstore SET name = 'foo', species = 'dog', ..., version = version + 1
+		WHERE id = 1 AND deleted_at IS NULL AND (0 = 2 OR version = 2);
+		SELECT version FROM petstore WHERE id = 1;
+		DELETE FROM pet_tags WHERE pet_id = 1;
+		INSERT INTO pet_tags(pet_id, tag_id, position) SELECT 1, id, 0 FROM tags WHERE name = 'bar';
+	*/
+
+	notaffected := -1
+	SQL := `UPDATE petstore SET name = :name, species = :species, breed = :breed, birth_date = :birth_date,
+		sex = :sex, status = :status, attributes = :attributes, version = version + 1
+		WHERE id = :id AND deleted_at IS NULL AND (:version = 0 OR version = :version)`
+
+	args, err := petArgs(&p.Pet)
 	if err != nil {
-		return nil, domain.Err500InternalServerError
+		return notaffected, err
 	}
+	args["version"] = p.Version
 
-	p.Id = i
+	var i int64
+	var version int64
+	err = transaction(ctx, impl.DB, func(ctx context.Context) error {
+		// access db
+		rslt, err := conn(ctx, impl.DB).NamedExecContext(ctx, SQL, args)
+		if err != nil {
+			return dbError(ctx, err)
+		}
 
-	return p, nil
+		i, err = rslt.RowsAffected()
+		if err != nil {
+			return dbError(ctx, err)
+		}
+
+		version, err = impl.queryPetVersion(ctx, int(p.Id))
+		if err != nil {
+			return err
+		}
+		if i == 0 && version != 0 {
+			// exists in another version
+			return domain.Err412PreconditionFailed
+		}
+		if i == 0 {
+			return nil
+		}
+		return impl.setPetTags(ctx, p.Id, p.Tags)
+	})
+	if err != nil {
+		return notaffected, err
+	}
+
+	p.Version = version
+	return int(i), nil
 }
 
-// DeletePet delete Pet from db.
-func (impl PetStoreRepositoryImpl) DeletePet(id int) (int, error) {
+// DeletePet mark Pet deleted in db.
+func (impl PetStoreRepositoryImpl) DeletePet(ctx context.Context, id int, version int64) (int, error) {
 	/*
-		DELETE FROM petstore WHERE id = 0
+		UPDATE petstore SET deleted_at = '2006-01-02 15:04:05', version = version + 1
+		WHERE id = 0 AND deleted_at IS NULL AND (0 = 2 OR version = 2);
+		SELECT version FROM petstore WHERE id = 1;
 	*/
 
 	notaffected := -1
-	SQL := `DELETE FROM petstore WHERE id = :id`
+	SQL := `UPDATE petstore SET deleted_at = :deleted_at, version = version + 1
+		WHERE id = :id AND deleted_at IS NULL AND (:version = 0 OR version = :version)`
 
-	// access db
-	stmt, err := impl.DB.Preparex(SQL)
+	var i int64
+	err := transaction(ctx, impl.DB, func(ctx context.Context) error {
+		// access db
+		rslt, err := conn(ctx, impl.DB).NamedExecContext(ctx, SQL, map[string]interface{}{
+			"deleted_at": time.Now().UTC(), "id": id, "version": version})
+		if err != nil {
+			return dbError(ctx, err)
+		}
+
+		i, err = rslt.RowsAffected()
+		if err != nil {
+			return dbError(ctx, err)
+		}
+		if i > 0 || version == 0 {
+			return nil
+		}
+
+		current, err := impl.queryPetVersion(ctx, id)
+		if err != nil {
+			return err
+		}
+		if current != 0 {
+			// exists in another version
+			return domain.Err412PreconditionFailed
+		}
+		return nil
+	})
 	if err != nil {
-		return notaffected, domain.Err500InternalServerError
+		return notaffected, err
 	}
-	defer stmt.Close()
 
-	rslt, err := stmt.Exec(id)
+	return int(i), nil
+}
+
+// RestorePet unmark Pet deleted in db.
+func (impl PetStoreRepositoryImpl) RestorePet(ctx context.Context, id int) (*domain.VersionedPet, error) {
+	/*
+		UPDATE petstore SET deleted_at = NULL, version = version + 1 WHERE id = 1 AND deleted_at IS NOT NULL;
+		SELECT id, name, species, breed, birth_date, sex, status, attributes, version FROM petstore WHERE id = 1;
+	*/
+
+	SQL := `UPDATE petstore SET deleted_at = NULL, version = version + 1 WHERE id = ? AND deleted_at IS NOT NULL`
+
+	var rslt *domain.VersionedPet
+	err := transaction(ctx, impl.DB, func(ctx context.Context) error {
+		db := conn(ctx, impl.DB)
+
+		// access db
+		res, err := db.ExecContext(ctx, SQL, id)
+		if err != nil {
+			return dbError(ctx, err)
+		}
+		i, err := res.RowsAffected()
+		if err != nil {
+			return dbError(ctx, err)
+		}
+		if i == 0 {
+			return nil
+		}
+
+		row := petRow{}
+		if err := db.GetContext(ctx, &row, `SELECT `+petColumns+`, version FROM petstore WHERE id = ?`, id); err != nil {
+			return dbError(ctx, err)
+		}
+		p, err := row.versionedPet()
+		if err != nil {
+			return err
+		}
+		rslt = &p
+		return loadPetTags(ctx, impl.DB, &rslt.Pet)
+	})
 	if err != nil {
-		return notaffected, domain.Err500InternalServerError
+		return nil, err
 	}
 
-	i, err := rslt.RowsAffected()
+	return rslt, nil
+}
+
+// PurgePets delete Pets deleted before deletedBefore from db.
+func (impl PetStoreRepositoryImpl) PurgePets(ctx context.Context, deletedBefore time.Time) (int, error) {
+	/*
+		DELETE FROM pet_tags WHERE pet_id IN (SELECT id FROM petstore WHERE deleted_at < '2006-01-02 15:04:05');
+		DELETE FROM pet_transitions WHERE pet_id IN (SELECT id FROM petstore WHERE deleted_at < '2006-01-02 15:04:05');
+		DELETE FROM adoptions WHERE pet_id IN (SELECT id FROM petstore WHERE deleted_at < '2006-01-02 15:04:05');
+		DELETE FROM petstore WHERE deleted_at < '2006-01-02 15:04:05';
+		DELETE FROM tags WHERE id NOT IN (SELECT tag_id FROM pet_tags);
+	*/
+
+	notaffected := -1
+	SQL := `DELETE FROM petstore WHERE deleted_at < ?`
+
+	var i int64
+	err := transaction(ctx, impl.DB, func(ctx context.Context) error {
+		db := conn(ctx, impl.DB)
+
+		// access db
+		_, err := db.ExecContext(ctx, `DELETE FROM pet_tags WHERE pet_id IN (SELECT id FROM petstore WHERE deleted_at < ?)`, deletedBefore.UTC())
+		if err != nil {
+			return dbError(ctx, err)
+		}
+		_, err = db.ExecContext(ctx, `DELETE FROM pet_transitions WHERE pet_id IN (SELECT id FROM petstore WHERE deleted_at < ?)`, deletedBefore.UTC())
+		if err != nil {
+			return dbError(ctx, err)
+		}
+		_, err = db.ExecContext(ctx, `DELETE FROM adoptions WHERE pet_id IN (SELECT id FROM petstore WHERE deleted_at < ?)`, deletedBefore.UTC())
+		if err != nil {
+			return dbError(ctx, err)
+		}
+		rslt, err := db.ExecContext(ctx, SQL, deletedBefore.UTC())
+		if err != nil {
+			return dbError(ctx, err)
+		}
+		i, err = rslt.RowsAffected()
+		if err != nil {
+			return dbError(ctx, err)
+		}
+		return impl.deleteUnusedTags(ctx)
+	})
 	if err != nil {
-		return notaffected, domain.Err500InternalServerError
+		return notaffected, err
 	}
 
 	return int(i), nil
 }
 
-// asMap cast QueryCondition to map[string]interface{}.
-func asMap(object *domain.QueryCondition) map[string]interface{} {
-	var i interface{}
-	b := new(bytes.Buffer)
-	json.NewEncoder(b).Encode(object)
-	json.Unmarshal(b.Bytes(), &i)
-	ret, _ := i.(map[string]interface{})
-	return ret
+// queryPetVersion returns the version of Pet, 0 if not exists or deleted.
+func (impl PetStoreRepositoryImpl) queryPetVersion(ctx context.Context, id int) (int64, error) {
+	var version int64
+	err := conn(ctx, impl.DB).GetContext(ctx, &version, `SELECT version FROM petstore WHERE id = ? AND deleted_at IS NULL`, id)
+	if err == sql.ErrNoRows {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, dbError(ctx, err)
+	}
+	return version, nil
+}
+
+// dbError returns domain error of db access error.
+// Cancellation and deadline of ctx are reported apart from db failure.
+func dbError(ctx context.Context, err error) error {
+	switch {
+	case errors.Is(err, context.Canceled) || errors.Is(ctx.Err(), context.Canceled):
+		return domain.Err499ClientClosedRequest.Wrap(err)
+	case errors.Is(err, context.DeadlineExceeded) || errors.Is(ctx.Err(), context.DeadlineExceeded):
+		return domain.Err504GatewayTimeout.Wrap(err)
+	default:
+		return domain.Err500InternalServerError.Wrap(err)
+	}
 }
@@ -0,0 +1,178 @@
+package repository
+
+import (
+	"fmt"
+	"strings"
+
+	openapi_types "github.com/deepmap/oapi-codegen/pkg/types"
+	"github.com/opbls/scapo/petstore/domain"
+)
+
+// sortColumns maps sort field to column.
+// Only columns listed here are written into ORDER BY.
+var sortColumns = map[domain.SortField]string{
+	domain.SortByID:   "id",
+	domain.SortByName: "name",
+}
+
+// petColumns are selected into petRow, tags are loaded apart.
+// deleted_at is aliased to the name sqlx maps Pet.DeletedAt to.
+const petColumns = `id, name, species, breed, birth_date, sex, status, attributes, deleted_at AS deletedat`
+
+// petColumnsP is petColumns of petstore aliased p.
+const petColumnsP = `p.id, p.name, p.species, p.breed, p.birth_date, p.sex, p.status, p.attributes, p.deleted_at AS deletedat`
+
+// petIDsTagged selects ids of Pets with any of the tags bound.
+const petIDsTagged = `SELECT pt.pet_id FROM pet_tags pt JOIN tags t ON t.id = pt.tag_id WHERE t.name IN (%s)`
+
+// compileQueryPets returns SELECT statement and its bind parameters.
+// User input is passed only through bind parameters.
+func compileQueryPets(q *domain.PetQuery) (string, []interface{}, error) {
+	SQL := `SELECT ` + petColumns + ` FROM petstore `
+	where := []string{}
+	args := []interface{}{}
+
+	if !q.Filter.IncludeDeleted {
+		where = append(where, `deleted_at IS NULL`)
+	}
+
+	if len(q.Tags) > 0 {
+		tags := domain.UniqueStrings(q.Tags)
+		tagged := fmt.Sprintf(petIDsTagged, placeholders(len(tags)))
+		for _, tag := range tags {
+			args = append(args, tag)
+		}
+		if q.TagMatch == domain.TagMatchAll {
+			// tags of a Pet are unique
+			tagged += ` GROUP BY pt.pet_id HAVING COUNT(*) = ?`
+			args = append(args, len(tags))
+		}
+		where = append(where, `id IN (`+tagged+`)`)
+	}
+	f := q.Filter
+	if f.NamePrefix != nil {
+		where = append(where, `name LIKE ? ESCAPE '\'`)
+		args = append(args, escapeLike(*f.NamePrefix)+"%")
+	}
+	if f.NameContains != nil {
+		where = append(where, `name LIKE ? ESCAPE '\'`)
+		args = append(args, "%"+escapeLike(*f.NameContains)+"%")
+	}
+	if f.IDGreaterThan != nil {
+		where = append(where, `id > ?`)
+		args = append(args, *f.IDGreaterThan)
+	}
+	if f.IDLessThan != nil {
+		where = append(where, `id < ?`)
+		args = append(args, *f.IDLessThan)
+	}
+	if f.Species != nil {
+		where = append(where, `species = ? COLLATE NOCASE`)
+		args = append(args, *f.Species)
+	}
+	if f.Breed != nil {
+		where = append(where, `breed = ? COLLATE NOCASE`)
+		args = append(args, *f.Breed)
+	}
+	if f.Sex != nil {
+		where = append(where, `sex = ?`)
+		args = append(args, *f.Sex)
+	}
+	if len(f.Statuses) > 0 {
+		statuses := domain.UniqueStrings(f.Statuses)
+		where = append(where, `status IN (`+placeholders(len(statuses))+`)`)
+		for _, status := range statuses {
+			args = append(args, status)
+		}
+	}
+	// dates are text of YYYY-MM-DD, ordered as strings
+	if f.BornAfter != nil {
+		where = append(where, `birth_date >= ?`)
+		args = append(args, f.BornAfter.Format(openapi_types.DateFormat))
+	}
+	if f.BornBefore != nil {
+		where = append(where, `birth_date <= ?`)
+		args = append(args, f.BornBefore.Format(openapi_types.DateFormat))
+	}
+	if f.HasTag != nil {
+		if *f.HasTag {
+			where = append(where, `id IN (SELECT pet_id FROM pet_tags)`)
+		} else {
+			where = append(where, `id NOT IN (SELECT pet_id FROM pet_tags)`)
+		}
+	}
+
+	sort := q.Sort
+	if len(sort) == 0 {
+		sort = []domain.SortKey{{Field: domain.SortByID}}
+	}
+	columns := []string{}
+	for _, k := range sort {
+		column, ok := sortColumns[k.Field]
+		if !ok {
+			return "", nil, domain.Err400BadRequest
+		}
+		columns = append(columns, column)
+	}
+
+	if q.After != nil {
+		cond, keysetArgs := keysetAfter(sort, columns, q.After)
+		where = append(where, cond)
+		args = append(args, keysetArgs...)
+	}
+
+	if len(where) > 0 {
+		SQL += `WHERE ` + strings.Join(where, ` AND `) + ` `
+	}
+	order := []string{}
+	for i, k := range sort {
+		if k.Desc {
+			order = append(order, columns[i]+` DESC`)
+		} else {
+			order = append(order, columns[i]+` ASC`)
+		}
+	}
+	SQL += `ORDER BY ` + strings.Join(order, `, `) + ` LIMIT ?`
+	args = append(args, q.Limit)
+
+	return SQL, args, nil
+}
+
+// keysetAfter returns condition selecting rows after the keyset in the sort order.
+func keysetAfter(sort []domain.SortKey, columns []string, after *domain.PetKeyset) (string, []interface{}) {
+	or := []string{}
+	args := []interface{}{}
+	for i := range sort {
+		and := []string{}
+		for j := 0; j < i; j++ {
+			and = append(and, columns[j]+` = ?`)
+			args = append(args, keysetValue(sort[j].Field, after))
+		}
+		op := ` > ?`
+		if sort[i].Desc {
+			op = ` < ?`
+		}
+		and = append(and, columns[i]+op)
+		args = append(args, keysetValue(sort[i].Field, after))
+		or = append(or, `(`+strings.Join(and, ` AND `)+`)`)
+	}
+	return `(` + strings.Join(or, ` OR `) + `)`, args
+}
+
+func keysetValue(field domain.SortField, after *domain.PetKeyset) interface{} {
+	switch field {
+	case domain.SortByName:
+		return after.Name
+	default:
+		return after.ID
+	}
+}
+
+func placeholders(n int) string {
+	return strings.TrimSuffix(strings.Repeat(`?, `, n), `, `)
+}
+
+// escapeLike escapes LIKE wildcards with '\'.
+func escapeLike(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(s)
+}
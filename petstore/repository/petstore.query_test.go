@@ -0,0 +1,279 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	openapi_types "github.com/deepmap/oapi-codegen/pkg/types"
+	"github.com/jmoiron/sqlx"
+	// sqlite driver
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/opbls/scapo/petstore/domain"
+	"github.com/opbls/scapo/petstore/migration"
+	"github.com/opbls/scapo/petstore/openapi"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestQueryPets(t *testing.T) {
+	db, _ := sqlx.Connect("sqlite3", ":memory:")
+	defer db.Close()
+	db.SetMaxOpenConns(1)
+
+	migrator, _ := migration.NewMigrator(db)
+	if _, err := migrator.Up(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	//////////////////
+	// TEST DATA
+	//////////////////
+	repo := NewPetStoreRepository(db)
+	names := []string{"cat", "Cow", "dog", "doge", "a_b", "a%b", "cat", "Dog", "bird", "cattle"}
+	all := domain.Pets{}
+	for i, name := range names {
+		p := domain.Pet{}
+		p.Name = name
+		species, sex, status := []string{"dog", "Cat"}[i%2], domain.PetSexes[i%3], domain.PetStatuses[i%3]
+		p.Species, p.Sex, p.Status = &species, &sex, &status
+		p.BirthDate = &openapi_types.Date{Time: time.Date(2020, 1, i+1, 0, 0, 0, 0, time.UTC)}
+		if i%5 != 0 {
+			breed := "mixed"
+			p.Breed = &breed
+		}
+		if i == 0 {
+			p.Attributes = &map[string]interface{}{"color": "brown", "weight": 4.5}
+		}
+		if i%3 != 0 {
+			tags := []string{fmt.Sprintf("tag%d", i%2)}
+			if i%4 == 1 {
+				tags = append(tags, "tag2")
+			}
+			p.Tag = &tags[0]
+			p.Tags = &tags
+		}
+		created := p
+		if _, err := repo.CreatePet(context.Background(), &created); err != nil {
+			t.Fatal(err)
+		}
+		p.Id = int64(i + 1)
+		all = append(all, openapi.Pet(p))
+	}
+
+	////////////////////
+	// TEST
+	////////////////////
+	sorts := [][]domain.SortKey{
+		nil,
+		{{Field: domain.SortByName}},
+		{{Field: domain.SortByName, Desc: true}},
+		{{Field: domain.SortByID, Desc: true}},
+		{{Field: domain.SortByName}, {Field: domain.SortByID, Desc: true}},
+	}
+	hasTags := []*bool{nil, boolp(true), boolp(false)}
+	after := domain.PetKeyset{ID: 7, Name: "cat"}
+
+	// every combination of the conditions
+	n := 0
+	for bits := 0; bits < 1<<8; bits++ {
+		for _, sortKeys := range sorts {
+			for _, hasTag := range hasTags {
+				b := domain.NewPetQueryBuilder().Limit(len(all))
+				if bits&1 != 0 {
+					b.Tags("tag0", "tag2", "tag0")
+				}
+				if bits&2 != 0 {
+					b.NamePrefix("do")
+				}
+				if bits&4 != 0 {
+					b.NameContains("a")
+				}
+				if bits&8 != 0 {
+					b.IDGreaterThan(2)
+				}
+				if bits&16 != 0 {
+					b.IDLessThan(9)
+				}
+				if bits&32 != 0 {
+					b.After(after)
+				}
+				if bits&64 != 0 {
+					b.Limit(2)
+				}
+				if bits&128 != 0 {
+					b.MatchTags(domain.TagMatchAll)
+				}
+				if hasTag != nil {
+					b.HasTag(*hasTag)
+				}
+				for _, k := range sortKeys {
+					b.SortBy(k.Field, k.Desc)
+				}
+				q, err := b.Build()
+				if !assert.NoError(t, err) {
+					return
+				}
+
+				rslt, err := repo.QueryPets(context.Background(), q)
+				assert.NoError(t, err)
+				assert.Equal(t, expectPets(all, q), *rslt, "bits=%b sort=%v has_tag=%v", bits, sortKeys, hasTag)
+				n++
+			}
+		}
+	}
+	assert.Equal(t, 256*5*3, n)
+
+	t.Run("SUCCESS_Escape", func(t *testing.T) {
+		q, _ := domain.NewPetQueryBuilder().Limit(10).NameContains("_").Build()
+		rslt, err := repo.QueryPets(context.Background(), q)
+		assert.NoError(t, err)
+		assert.Equal(t, 1, len(*rslt))
+
+		q, _ = domain.NewPetQueryBuilder().Limit(10).NameContains("%").Build()
+		rslt, err = repo.QueryPets(context.Background(), q)
+		assert.NoError(t, err)
+		assert.Equal(t, 1, len(*rslt))
+	})
+
+	t.Run("SUCCESS_Profile", func(t *testing.T) {
+		ids := func(b *domain.PetQueryBuilder) []int64 {
+			q, err := b.Limit(len(all)).Build()
+			if !assert.NoError(t, err) {
+				return nil
+			}
+			rslt, err := repo.QueryPets(context.Background(), q)
+			assert.NoError(t, err)
+			rslts := []int64{}
+			for _, p := range *rslt {
+				rslts = append(rslts, p.Id)
+			}
+			return rslts
+		}
+		born := func(day int) time.Time { return time.Date(2020, 1, day, 0, 0, 0, 0, time.UTC) }
+
+		assert.Equal(t, []int64{1, 3, 5, 7, 9}, ids(domain.NewPetQueryBuilder().Species("DOG")))
+		assert.Equal(t, []int64{2, 3, 4, 5, 7, 8, 9, 10}, ids(domain.NewPetQueryBuilder().Breed("Mixed")))
+		assert.Equal(t, []int64{2, 5, 8}, ids(domain.NewPetQueryBuilder().Sex(domain.PetSexFemale)))
+		assert.Equal(t, []int64{2, 3, 5, 6, 8, 9}, ids(domain.NewPetQueryBuilder().Statuses(domain.PetStatusOnHold, domain.PetStatusReserved)))
+		assert.Equal(t, []int64{3, 4, 5}, ids(domain.NewPetQueryBuilder().BornAfter(born(3)).BornBefore(born(5))))
+		assert.Equal(t, []int64{3, 9}, ids(domain.NewPetQueryBuilder().Species("dog").Statuses(domain.PetStatusReserved)))
+
+		// profile is read as written
+		p, err := repo.QueryPet(context.Background(), 1)
+		assert.NoError(t, err)
+		assert.Equal(t, all[0].NewPet, p.NewPet)
+	})
+
+	t.Run("SUCCESS_Compile_Parameterized", func(t *testing.T) {
+		q, _ := domain.NewPetQueryBuilder().Limit(1).Tags("'; DROP TABLE petstore; --").NamePrefix("' OR 1=1 --").Build()
+		SQL, args, err := compileQueryPets(q)
+		assert.NoError(t, err)
+		assert.NotContains(t, SQL, "DROP")
+		assert.NotContains(t, SQL, "1=1")
+		assert.Equal(t, strings.Count(SQL, "?"), len(args))
+	})
+
+	t.Run("ABNORMAL_Build", func(t *testing.T) {
+		_, err := domain.NewPetQueryBuilder().Limit(-1).Build()
+		assert.Equal(t, domain.Err400BadRequest, err)
+
+		_, err = domain.NewPetQueryBuilder().SortBy(domain.SortByName, false).SortBy(domain.SortByName, true).Build()
+		assert.Equal(t, domain.Err400BadRequest, err)
+
+		_, err = domain.NewPetQueryBuilder().SortBy(domain.SortField("tag"), false).Build()
+		assert.Equal(t, domain.Err400BadRequest, err)
+
+		_, err = domain.NewPetQueryBuilder().MatchTags(domain.TagMatch("none")).Build()
+		assert.Equal(t, domain.Err400BadRequest, err)
+
+		_, err = domain.NewPetQueryBuilder().Sex("none").Build()
+		assert.Equal(t, domain.Err400BadRequest, err)
+
+		_, err = domain.NewPetQueryBuilder().Statuses(domain.PetStatusAvailable, "sold").Build()
+		assert.Equal(t, domain.Err400BadRequest, err)
+	})
+}
+
+// expectPets is the reference implementation of QueryPets.
+func expectPets(all domain.Pets, q *domain.PetQuery) domain.Pets {
+	rslt := domain.Pets{}
+	for _, p := range all {
+		if len(q.Tags) > 0 && !matchTags(q.Tags, q.TagMatch, p.Tags) {
+			continue
+		}
+		f := q.Filter
+		name := strings.ToLower(p.Name)
+		if f.NamePrefix != nil && !strings.HasPrefix(name, strings.ToLower(*f.NamePrefix)) {
+			continue
+		}
+		if f.NameContains != nil && !strings.Contains(name, strings.ToLower(*f.NameContains)) {
+			continue
+		}
+		if f.IDGreaterThan != nil && p.Id <= *f.IDGreaterThan {
+			continue
+		}
+		if f.IDLessThan != nil && p.Id >= *f.IDLessThan {
+			continue
+		}
+		if f.HasTag != nil && *f.HasTag != (p.Tags != nil) {
+			continue
+		}
+		if q.After != nil && compareKeyset(q.Sort, p.Id, p.Name, q.After.ID, q.After.Name) <= 0 {
+			continue
+		}
+		rslt = append(rslt, p)
+	}
+	sort.SliceStable(rslt, func(i, j int) bool {
+		return compareKeyset(q.Sort, rslt[i].Id, rslt[i].Name, rslt[j].Id, rslt[j].Name) < 0
+	})
+	if len(rslt) > q.Limit {
+		rslt = rslt[:q.Limit]
+	}
+	return rslt
+}
+
+func matchTags(tags []string, match domain.TagMatch, petTags *[]string) bool {
+	if petTags == nil {
+		return false
+	}
+	for _, tag := range tags {
+		has := domain.ContainsString(*petTags, tag)
+		if has && match != domain.TagMatchAll {
+			return true
+		}
+		if !has && match == domain.TagMatchAll {
+			return false
+		}
+	}
+	return match == domain.TagMatchAll
+}
+
+func compareKeyset(keys []domain.SortKey, id1 int64, name1 string, id2 int64, name2 string) int {
+	for _, k := range keys {
+		c := 0
+		switch k.Field {
+		case domain.SortByName:
+			c = strings.Compare(name1, name2)
+		case domain.SortByID:
+			if id1 < id2 {
+				c = -1
+			} else if id1 > id2 {
+				c = 1
+			}
+		}
+		if k.Desc {
+			c = -c
+		}
+		if c != 0 {
+			return c
+		}
+	}
+	return 0
+}
+
+func boolp(b bool) *bool {
+	return &b
+}
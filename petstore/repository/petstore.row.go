@@ -0,0 +1,67 @@
+package repository
+
+import (
+	"encoding/json"
+	"time"
+
+	openapi_types "github.com/deepmap/oapi-codegen/pkg/types"
+	"github.com/opbls/scapo/petstore/domain"
+)
+
+// petRow is a row of petstore.
+// birth_date and attributes are text in db, they shadow the fields of Pet sqlx can not scan into.
+type petRow struct {
+	domain.VersionedPet
+	BirthDate  *string `db:"birth_date"`
+	Attributes *string `db:"attributes"`
+}
+
+// versionedPet returns Pet of the row.
+func (r petRow) versionedPet() (domain.VersionedPet, error) {
+	p := r.VersionedPet
+	p.BirthDate, p.Attributes = nil, nil
+	if r.BirthDate != nil {
+		t, err := time.Parse(openapi_types.DateFormat, *r.BirthDate)
+		if err != nil {
+			return p, domain.Err500InternalServerError.Wrap(err)
+		}
+		p.BirthDate = &openapi_types.Date{Time: t}
+	}
+	if r.Attributes != nil {
+		attributes := map[string]interface{}{}
+		if err := json.Unmarshal([]byte(*r.Attributes), &attributes); err != nil {
+			return p, domain.Err500InternalServerError.Wrap(err)
+		}
+		p.Attributes = &attributes
+	}
+	return p, nil
+}
+
+// petArgs returns named parameters of the columns of Pet.
+// status is available if absent, as the default of the column.
+func petArgs(p *domain.Pet) (map[string]interface{}, error) {
+	args := map[string]interface{}{
+		"id":         p.Id,
+		"name":       p.Name,
+		"species":    p.Species,
+		"breed":      p.Breed,
+		"birth_date": nil,
+		"sex":        p.Sex,
+		"status":     domain.PetStatusAvailable,
+		"attributes": nil,
+	}
+	if p.BirthDate != nil {
+		args["birth_date"] = p.BirthDate.Format(openapi_types.DateFormat)
+	}
+	if p.Status != nil {
+		args["status"] = *p.Status
+	}
+	if p.Attributes != nil {
+		b, err := json.Marshal(p.Attributes)
+		if err != nil {
+			return nil, domain.Err400BadRequest.Wrap(err)
+		}
+		args["attributes"] = string(b)
+	}
+	return args, nil
+}
@@ -0,0 +1,304 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"html"
+	"strings"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/opbls/scapo/petstore/domain"
+)
+
+// highlightStart and highlightEnd enclose matched terms in snippets of the search index,
+// replaced by domain.HighlightStart and domain.HighlightEnd after the text is escaped.
+const (
+	highlightStart = "\x02"
+	highlightEnd   = "\x03"
+)
+
+// petTagsText selects tags of the Pet of id %s separated by spaces, for the search index.
+const petTagsText = `(SELECT group_concat(t.name, ' ') FROM pet_tags pt JOIN tags t ON t.id = pt.tag_id WHERE pt.pet_id = %s)`
+
+// searchSchema is the FTS5 index of Pets and the triggers keeping it in sync with petstore and pet_tags.
+// It is derived from them, so it is set up by SetupSearch instead of a migration,
+// and recreated when the definitions here differ from sqlite_master.
+var searchSchema = []struct {
+	Name string
+	SQL  string
+}{
+	{"pets_fts", `CREATE VIRTUAL TABLE pets_fts USING fts5(name, tags)`},
+	{"pets_fts_insert", `CREATE TRIGGER pets_fts_insert AFTER INSERT ON petstore BEGIN
+		INSERT INTO pets_fts(rowid, name, tags) VALUES (new.id, new.name, ` + fmt.Sprintf(petTagsText, "new.id") + `);
+	END`},
+	{"pets_fts_update", `CREATE TRIGGER pets_fts_update AFTER UPDATE OF name ON petstore BEGIN
+		UPDATE pets_fts SET name = new.name WHERE rowid = new.id;
+	END`},
+	{"pets_fts_delete", `CREATE TRIGGER pets_fts_delete AFTER DELETE ON petstore BEGIN
+		DELETE FROM pets_fts WHERE rowid = old.id;
+	END`},
+	{"pets_fts_tag_insert", `CREATE TRIGGER pets_fts_tag_insert AFTER INSERT ON pet_tags BEGIN
+		UPDATE pets_fts SET tags = ` + fmt.Sprintf(petTagsText, "pets_fts.rowid") + ` WHERE rowid = new.pet_id;
+	END`},
+	{"pets_fts_tag_update", `CREATE TRIGGER pets_fts_tag_update AFTER UPDATE ON pet_tags BEGIN
+		UPDATE pets_fts SET tags = ` + fmt.Sprintf(petTagsText, "pets_fts.rowid") + ` WHERE rowid IN (old.pet_id, new.pet_id);
+	END`},
+	{"pets_fts_tag_delete", `CREATE TRIGGER pets_fts_tag_delete AFTER DELETE ON pet_tags BEGIN
+		UPDATE pets_fts SET tags = ` + fmt.Sprintf(petTagsText, "pets_fts.rowid") + ` WHERE rowid = old.pet_id;
+	END`},
+	{"pets_fts_tag_rename", `CREATE TRIGGER pets_fts_tag_rename AFTER UPDATE OF name ON tags BEGIN
+		UPDATE pets_fts SET tags = ` + fmt.Sprintf(petTagsText, "pets_fts.rowid") + `
+		WHERE rowid IN (SELECT pet_id FROM pet_tags WHERE tag_id = new.id);
+	END`},
+}
+
+// SetupSearch creates the search index of Pets if sqlite is built with FTS5, and reports whether it is.
+// Without FTS5 the triggers are dropped, writes to a database indexed by another build keep working,
+// and SearchPets falls back on LIKE. The schema must be migrated.
+func SetupSearch(ctx context.Context, db *sqlx.DB) (bool, error) {
+	/*
+		SELECT sqlite_compileoption_used('ENABLE_FTS5');
+		SELECT name, sql FROM sqlite_master WHERE name LIKE 'pets_fts%' AND sql IS NOT NULL;
+		DROP TRIGGER IF EXISTS pets_fts_insert; DROP TABLE IF EXISTS pets_fts;
+		CREATE VIRTUAL TABLE pets_fts USING fts5(name, tags); CREATE TRIGGER pets_fts_insert ...;
+		INSERT INTO pets_fts(rowid, name, tags) SELECT id, name, (SELECT group_concat(...)) FROM petstore;
+	*/
+
+	var fts5 bool
+	if err := db.GetContext(ctx, &fts5, `SELECT sqlite_compileoption_used('ENABLE_FTS5')`); err != nil {
+		return false, dbError(ctx, err)
+	}
+
+	err := transaction(ctx, db, func(ctx context.Context) error {
+		tx := conn(ctx, db)
+
+		rows := []struct {
+			Name string `db:"name"`
+			SQL  string `db:"sql"`
+		}{}
+		if err := tx.SelectContext(ctx, &rows, `SELECT name, sql FROM sqlite_master WHERE name LIKE 'pets_fts%' AND sql IS NOT NULL`); err != nil {
+			return dbError(ctx, err)
+		}
+		current := map[string]string{}
+		for _, row := range rows {
+			current[row.Name] = row.SQL
+		}
+		upToDate := true
+		for _, s := range searchSchema {
+			upToDate = upToDate && current[s.Name] == s.SQL
+		}
+		if fts5 && upToDate {
+			return nil
+		}
+
+		for _, s := range searchSchema[1:] {
+			if _, err := tx.ExecContext(ctx, `DROP TRIGGER IF EXISTS `+s.Name); err != nil {
+				return dbError(ctx, err)
+			}
+		}
+		// the index can not be dropped without FTS5, it is left until the next build with FTS5
+		if !fts5 {
+			return nil
+		}
+		if _, err := tx.ExecContext(ctx, `DROP TABLE IF EXISTS pets_fts`); err != nil {
+			return dbError(ctx, err)
+		}
+		for _, s := range searchSchema {
+			if _, err := tx.ExecContext(ctx, s.SQL); err != nil {
+				return dbError(ctx, err)
+			}
+		}
+		SQL := `INSERT INTO pets_fts(rowid, name, tags) SELECT id, name, ` + fmt.Sprintf(petTagsText, "petstore.id") + ` FROM petstore`
+		if _, err := tx.ExecContext(ctx, SQL); err != nil {
+			return dbError(ctx, err)
+		}
+		return nil
+	})
+	if err != nil {
+		return false, err
+	}
+
+	return fts5, nil
+}
+
+// SearchPets return Pets matching search from db.
+func (impl PetStoreRepositoryImpl) SearchPets(ctx context.Context, search *domain.PetSearch) (*domain.PetSearchHits, error) {
+	/*
+		SELECT p.id, p.name, ..., snippet(pets_fts, -1, '<mark>', '</mark>', '…', 16) AS highlight
+		FROM pets_fts JOIN petstore p ON p.id = pets_fts.rowid
+		WHERE pets_fts MATCH '"foo"* "bar"*' AND p.deleted_at IS NULL
+		ORDER BY bm25(pets_fts), p.id LIMIT 10 OFFSET 0;
+	*/
+
+	indexed, err := impl.searchIndexed(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// build sql
+	terms := search.Terms()
+	var SQL string
+	var args []interface{}
+	if indexed {
+		SQL, args = compileSearchPets(search, terms)
+	} else {
+		SQL, args = compileSearchPetsLike(search, terms)
+	}
+
+	// access db
+	rows := []struct {
+		petRow
+		Highlight *string `db:"highlight"`
+	}{}
+	if err := conn(ctx, impl.DB).SelectContext(ctx, &rows, SQL, args...); err != nil {
+		return nil, dbError(ctx, err)
+	}
+	rslts := domain.PetSearchHits{}
+	for _, row := range rows {
+		p, err := row.versionedPet()
+		if err != nil {
+			return nil, err
+		}
+		rslts = append(rslts, domain.PetSearchHit{Pet: p.Pet, Highlight: row.Highlight})
+	}
+
+	pets := []*domain.Pet{}
+	for i := range rslts {
+		pets = append(pets, &rslts[i].Pet)
+	}
+	if err := loadPetTags(ctx, impl.DB, pets...); err != nil {
+		return nil, err
+	}
+	if search.Highlight {
+		for i := range rslts {
+			if indexed {
+				rslts[i].Highlight = escapeSnippet(rslts[i].Highlight)
+			} else {
+				rslts[i].Highlight = highlightLike(rslts[i].Pet, terms)
+			}
+		}
+	}
+
+	return &rslts, nil
+}
+
+// searchIndexed reports whether the search index set up by SetupSearch is usable.
+func (impl PetStoreRepositoryImpl) searchIndexed(ctx context.Context) (bool, error) {
+	SQL := `SELECT sqlite_compileoption_used('ENABLE_FTS5')
+		AND EXISTS (SELECT 1 FROM sqlite_master WHERE type = 'trigger' AND name = 'pets_fts_insert')`
+
+	var rslt bool
+	if err := conn(ctx, impl.DB).GetContext(ctx, &rslt, SQL); err != nil {
+		return false, dbError(ctx, err)
+	}
+	return rslt, nil
+}
+
+// compileSearchPets returns SELECT statement of the search index ranked by bm25.
+// Terms are quoted as FTS5 strings and match tokens starting with them.
+func compileSearchPets(search *domain.PetSearch, terms []string) (string, []interface{}) {
+	phrases := []string{}
+	for _, term := range terms {
+		phrases = append(phrases, `"`+strings.ReplaceAll(term, `"`, `""`)+`"*`)
+	}
+
+	highlight := `NULL`
+	if search.Highlight {
+		highlight = `snippet(pets_fts, -1, '` + highlightStart + `', '` + highlightEnd + `', '…', 16)`
+	}
+	SQL := `SELECT ` + petColumnsP + `, ` + highlight + ` AS highlight
+		FROM pets_fts JOIN petstore p ON p.id = pets_fts.rowid
+		WHERE pets_fts MATCH ? AND p.deleted_at IS NULL
+		ORDER BY bm25(pets_fts), p.id LIMIT ? OFFSET ?`
+	return SQL, []interface{}{strings.Join(phrases, " "), search.Limit, search.Offset}
+}
+
+// compileSearchPetsLike returns SELECT statement matching terms anywhere in name or tags by LIKE.
+// Pets are ranked by the number of terms in the name, then shorter names.
+func compileSearchPetsLike(search *domain.PetSearch, terms []string) (string, []interface{}) {
+	where := []string{`p.deleted_at IS NULL`}
+	rank := []string{}
+	args := []interface{}{}
+	rankArgs := []interface{}{}
+	for _, term := range terms {
+		like := "%" + escapeLike(term) + "%"
+		where = append(where, `(p.name LIKE ? ESCAPE '\' OR p.id IN (
+			SELECT pt.pet_id FROM pet_tags pt JOIN tags t ON t.id = pt.tag_id WHERE t.name LIKE ? ESCAPE '\'))`)
+		args = append(args, like, like)
+		rank = append(rank, `(p.name LIKE ? ESCAPE '\')`)
+		rankArgs = append(rankArgs, like)
+	}
+
+	SQL := `SELECT ` + petColumnsP + `, NULL AS highlight FROM petstore p
+		WHERE ` + strings.Join(where, " AND ") + `
+		ORDER BY ` + strings.Join(rank, " + ") + ` DESC, length(p.name), p.id LIMIT ? OFFSET ?`
+	args = append(args, rankArgs...)
+	args = append(args, search.Limit, search.Offset)
+	return SQL, args
+}
+
+// highlightLike returns name, or tags if terms are not in the name, with terms marked as LIKE matches them.
+func highlightLike(p domain.Pet, terms []string) *string {
+	text := p.Name
+	if marked := markTerms(text, terms); marked != html.EscapeString(text) {
+		return &marked
+	}
+	if p.Tags != nil {
+		text = strings.Join(*p.Tags, " ")
+	}
+	marked := markTerms(text, terms)
+	return &marked
+}
+
+// escapeSnippet returns snippet of the search index HTML escaped, with matched terms in marks.
+func escapeSnippet(snippet *string) *string {
+	if snippet == nil {
+		return nil
+	}
+	rslt := strings.NewReplacer(highlightStart, domain.HighlightStart, highlightEnd, domain.HighlightEnd).Replace(html.EscapeString(*snippet))
+	return &rslt
+}
+
+// markTerms encloses occurrences of terms in text, ASCII case insensitive as LIKE.
+// Text is HTML escaped, only the marks are HTML.
+func markTerms(text string, terms []string) string {
+	lower := lowerASCII(text)
+
+	// marked[i] is whether byte i of text is in a term
+	marked := make([]bool, len(text))
+	for _, term := range terms {
+		term = lowerASCII(term)
+		for i := 0; i < len(lower); {
+			j := strings.Index(lower[i:], term)
+			if j < 0 {
+				break
+			}
+			for k := i + j; k < i+j+len(term); k++ {
+				marked[k] = true
+			}
+			i += j + 1
+		}
+	}
+
+	b := strings.Builder{}
+	for i := 0; i < len(text); i++ {
+		if marked[i] && (i == 0 || !marked[i-1]) {
+			b.WriteString(domain.HighlightStart)
+		}
+		b.WriteString(html.EscapeString(text[i : i+1]))
+		if marked[i] && (i == len(text)-1 || !marked[i+1]) {
+			b.WriteString(domain.HighlightEnd)
+		}
+	}
+	return b.String()
+}
+
+func lowerASCII(s string) string {
+	b := []byte(s)
+	for i, c := range b {
+		if 'A' <= c && c <= 'Z' {
+			b[i] = c + 'a' - 'A'
+		}
+	}
+	return string(b)
+}
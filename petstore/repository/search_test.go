@@ -0,0 +1,131 @@
+package repository
+
+import (
+	"context"
+	"testing"
+
+	"github.com/jmoiron/sqlx"
+	// sqlite driver
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/opbls/scapo/petstore/domain"
+	"github.com/opbls/scapo/petstore/migration"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSearchPets(t *testing.T) {
+	ctx := context.Background()
+
+	db, _ := sqlx.Connect("sqlite3", ":memory:")
+	defer db.Close()
+	db.SetMaxOpenConns(1)
+
+	migrator, _ := migration.NewMigrator(db)
+	if _, err := migrator.Up(ctx); err != nil {
+		t.Fatal(err)
+	}
+	repo := NewPetStoreRepository(db)
+
+	//////////////////
+	// TEST DATA
+	//////////////////
+	create := func(name string, tags ...string) {
+		p := domain.Pet{}
+		p.Name = name
+		if tags != nil {
+			p.Tags = &tags
+		}
+		if _, err := repo.CreatePet(ctx, &p); err != nil {
+			t.Fatal(err)
+		}
+	}
+	create("Fluffy", "cat", "white")
+	create("Rex", "dog", "fluffy")
+	create("Fluffy Rex", "dog")
+	create("Tom", "cat")
+	create("Fluffy Ghost", "cat")
+	repo.DeletePet(ctx, 5, 0)
+	create(`<script>alert("Spot")</script>`, "a&b")
+
+	search := func(q string, limit int, offset int) []string {
+		rslt, err := repo.SearchPets(ctx, &domain.PetSearch{Query: q, Limit: limit, Offset: offset})
+		if !assert.NoError(t, err) {
+			return nil
+		}
+		names := []string{}
+		for _, hit := range *rslt {
+			assert.Nil(t, hit.Highlight)
+			names = append(names, hit.Name)
+		}
+		return names
+	}
+	highlight := func(q string) []string {
+		rslt, err := repo.SearchPets(ctx, &domain.PetSearch{Query: q, Highlight: true, Limit: 10})
+		if !assert.NoError(t, err) {
+			return nil
+		}
+		rslts := []string{}
+		for _, hit := range *rslt {
+			rslts = append(rslts, *hit.Highlight)
+		}
+		return rslts
+	}
+
+	////////////////////
+	// TEST
+	////////////////////
+	t.Run("SUCCESS_Like", func(t *testing.T) {
+		// pets with all terms in name or tags, ranked by terms in name
+		assert.Equal(t, []string{"Fluffy", "Fluffy Rex", "Rex"}, search("fluf", 10, 0))
+		assert.Equal(t, []string{"Fluffy Rex", "Rex"}, search("rex FLUFFY", 10, 0))
+		assert.Equal(t, []string{"Tom", "Fluffy"}, search("cat", 10, 0))
+		// matches anywhere in words
+		assert.Equal(t, []string{"Fluffy", "Fluffy Rex", "Rex"}, search("uff", 10, 0))
+		assert.Equal(t, []string{}, search(`%`, 10, 0))
+
+		assert.Equal(t, []string{"Fluffy Rex"}, search("fluf", 1, 1))
+		assert.Equal(t, []string{"<mark>Rex</mark>", "Fluffy <mark>Rex</mark>"}, highlight("rex"))
+		assert.Equal(t, []string{"<mark>cat</mark>", "<mark>cat</mark> white"}, highlight("CAT"))
+		// only marks are HTML
+		assert.Equal(t, []string{`&lt;<mark>script</mark>&gt;alert(&#34;<mark>Spot</mark>&#34;)&lt;/<mark>script</mark>&gt;`}, highlight("spot script"))
+		assert.Equal(t, []string{`<mark>a&amp;b</mark>`}, highlight("a&b"))
+	})
+
+	fts5, err := SetupSearch(ctx, db)
+	if !assert.NoError(t, err) {
+		return
+	}
+	// SetupSearch is idempotent
+	_, err = SetupSearch(ctx, db)
+	assert.NoError(t, err)
+
+	t.Run("SUCCESS_FTS5", func(t *testing.T) {
+		if !fts5 {
+			t.Skip("sqlite is built without FTS5, test with -tags sqlite_fts5")
+		}
+		// pets with words starting with all terms, indexed before SetupSearch
+		assert.ElementsMatch(t, []string{"Fluffy", "Fluffy Rex", "Rex"}, search("fluf", 10, 0))
+		assert.Equal(t, []string{}, search("uff", 10, 0))
+		assert.ElementsMatch(t, []string{"Fluffy Rex", "Rex"}, search("rex fluffy", 10, 0))
+		assert.Equal(t, []string{}, search(`"`, 10, 0))
+		assert.Len(t, search("fluf", 1, 2), 1)
+
+		// kept in sync by triggers
+		create("Snowball", "cat", "white")
+		p, _ := repo.QueryPet(ctx, 4)
+		p.Name = "Tom Fluffy"
+		repo.UpdatePet(ctx, p)
+		repo.RenameTag(ctx, "white", "snow")
+		repo.MergeTags(ctx, []string{"dog"}, "hound")
+		repo.RestorePet(ctx, 5)
+		repo.DeletePet(ctx, 1, 0)
+
+		assert.Equal(t, []string{"Snowball"}, search("snowb", 10, 0))
+		assert.Equal(t, []string{}, search("white", 10, 0))
+		assert.Equal(t, []string{}, search("dog", 10, 0))
+		assert.ElementsMatch(t, []string{"Rex", "Fluffy Rex"}, search("hound", 10, 0))
+		assert.ElementsMatch(t, []string{"Rex", "Fluffy Rex", "Tom Fluffy", "Fluffy Ghost"}, search("fluffy", 10, 0))
+		assert.ElementsMatch(t, []string{"<mark>Fluffy</mark> <mark>Ghost</mark>"}, highlight("ghost fluffy"))
+		// only marks are HTML
+		assert.Equal(t, []string{`&lt;<mark>script</mark>&gt;alert(&#34;<mark>Spot</mark>&#34;)&lt;/<mark>script</mark>&gt;`}, highlight("spot script"))
+	})
+}
@@ -0,0 +1,268 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/opbls/scapo/petstore/domain"
+)
+
+// petTagsChunk is the number of Pets loadPetTags binds in a statement.
+const petTagsChunk = 500
+
+// QueryTags return Tags from db.
+func (impl PetStoreRepositoryImpl) QueryTags(ctx context.Context) (*domain.Tags, error) {
+	/*
+		SELECT t.name, COUNT(p.id) AS count FROM tags t
+		LEFT JOIN pet_tags pt ON pt.tag_id = t.id LEFT JOIN petstore p ON p.id = pt.pet_id AND p.deleted_at IS NULL
+		GROUP BY t.id ORDER BY t.name;
+	*/
+
+	SQL := `SELECT t.name, COUNT(p.id) AS count FROM tags t
+		LEFT JOIN pet_tags pt ON pt.tag_id = t.id LEFT JOIN petstore p ON p.id = pt.pet_id AND p.deleted_at IS NULL
+		GROUP BY t.id ORDER BY t.name`
+
+	// access db
+	rslts := domain.Tags{}
+	if err := conn(ctx, impl.DB).SelectContext(ctx, &rslts, SQL); err != nil {
+		return nil, dbError(ctx, err)
+	}
+
+	return &rslts, nil
+}
+
+// RenameTag rename Tag in db.
+func (impl PetStoreRepositoryImpl) RenameTag(ctx context.Context, from string, to string) (*domain.Tag, error) {
+	/*
+		SELECT id FROM tags WHERE name = 'foo';
+		SELECT COUNT(*) FROM tags WHERE name = 'bar';
+		UPDATE tags SET name = 'bar' WHERE id = 1;
+		UPDATE petstore SET version = version + 1 WHERE id IN (SELECT pet_id FROM pet_tags WHERE tag_id IN (1));
+	*/
+
+	var rslt *domain.Tag
+	err := transaction(ctx, impl.DB, func(ctx context.Context) error {
+		db := conn(ctx, impl.DB)
+
+		ids, err := impl.queryTagIDs(ctx, []string{from})
+		if err != nil || len(ids) == 0 {
+			return err
+		}
+
+		var n int
+		if err := db.GetContext(ctx, &n, `SELECT COUNT(*) FROM tags WHERE name = ?`, to); err != nil {
+			return dbError(ctx, err)
+		}
+		if n > 0 {
+			return domain.Err409Conflict.WithViolations(domain.Violation{
+				Field: "to", Code: "exists", Message: "tag already exists, merge into it instead"})
+		}
+
+		if _, err := db.ExecContext(ctx, `UPDATE tags SET name = ? WHERE id = ?`, to, ids[0]); err != nil {
+			return dbError(ctx, err)
+		}
+		if err := impl.touchTaggedPets(ctx, ids); err != nil {
+			return err
+		}
+
+		rslt, err = impl.queryTag(ctx, ids[0])
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return rslt, nil
+}
+
+// MergeTags replace Tags by into in db.
+func (impl PetStoreRepositoryImpl) MergeTags(ctx context.Context, from []string, into string) (*domain.Tag, error) {
+	/*
+		SELECT id FROM tags WHERE name IN ('foo', 'bar');
+		INSERT INTO tags(name) VALUES('baz') ON CONFLICT(name) DO NOTHING;
+		UPDATE petstore SET version = version + 1 WHERE id IN (SELECT pet_id FROM pet_tags WHERE tag_id IN (1, 2));
+		DELETE FROM pet_tags WHERE tag_id IN (1, 2) AND EXISTS (...);
+		UPDATE pet_tags SET tag_id = 3 WHERE tag_id IN (1, 2);
+		DELETE FROM tags WHERE id IN (1, 2);
+	*/
+
+	var rslt *domain.Tag
+	err := transaction(ctx, impl.DB, func(ctx context.Context) error {
+		db := conn(ctx, impl.DB)
+
+		ids, err := impl.queryTagIDs(ctx, from)
+		if err != nil || len(ids) != len(from) {
+			return err
+		}
+
+		_, err = db.ExecContext(ctx, `INSERT INTO tags(name) VALUES(?) ON CONFLICT(name) DO NOTHING`, into)
+		if err != nil {
+			return dbError(ctx, err)
+		}
+		var intoID int64
+		if err := db.GetContext(ctx, &intoID, `SELECT id FROM tags WHERE name = ?`, into); err != nil {
+			return dbError(ctx, err)
+		}
+
+		if err := impl.touchTaggedPets(ctx, ids); err != nil {
+			return err
+		}
+
+		in := placeholders(len(ids))
+		args := []interface{}{}
+		for _, id := range ids {
+			args = append(args, id)
+		}
+		// a Pet keeps into, or the first of the merged tags
+		SQL := `DELETE FROM pet_tags WHERE tag_id IN (` + in + `) AND EXISTS (
+			SELECT 1 FROM pet_tags o WHERE o.pet_id = pet_tags.pet_id AND o.tag_id <> pet_tags.tag_id
+			AND (o.tag_id = ? OR (o.tag_id IN (` + in + `) AND o.position < pet_tags.position)))`
+		deleteArgs := append([]interface{}{}, args...)
+		deleteArgs = append(deleteArgs, intoID)
+		deleteArgs = append(deleteArgs, args...)
+		if _, err := db.ExecContext(ctx, SQL, deleteArgs...); err != nil {
+			return dbError(ctx, err)
+		}
+		SQL = `UPDATE pet_tags SET tag_id = ? WHERE tag_id IN (` + in + `)`
+		if _, err := db.ExecContext(ctx, SQL, append([]interface{}{intoID}, args...)...); err != nil {
+			return dbError(ctx, err)
+		}
+		if _, err := db.ExecContext(ctx, `DELETE FROM tags WHERE id IN (`+in+`)`, args...); err != nil {
+			return dbError(ctx, err)
+		}
+
+		rslt, err = impl.queryTag(ctx, intoID)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return rslt, nil
+}
+
+// queryTag returns Tag of id.
+func (impl PetStoreRepositoryImpl) queryTag(ctx context.Context, id int64) (*domain.Tag, error) {
+	SQL := `SELECT t.name, COUNT(p.id) AS count FROM tags t
+		LEFT JOIN pet_tags pt ON pt.tag_id = t.id LEFT JOIN petstore p ON p.id = pt.pet_id AND p.deleted_at IS NULL
+		WHERE t.id = ? GROUP BY t.id`
+
+	rslt := domain.Tag{}
+	if err := conn(ctx, impl.DB).GetContext(ctx, &rslt, SQL, id); err != nil {
+		return nil, dbError(ctx, err)
+	}
+	return &rslt, nil
+}
+
+// queryTagIDs returns ids of existing tags of names.
+func (impl PetStoreRepositoryImpl) queryTagIDs(ctx context.Context, names []string) ([]int64, error) {
+	args := []interface{}{}
+	for _, name := range names {
+		args = append(args, name)
+	}
+
+	rslts := []int64{}
+	SQL := `SELECT id FROM tags WHERE name IN (` + placeholders(len(names)) + `)`
+	if err := conn(ctx, impl.DB).SelectContext(ctx, &rslts, SQL, args...); err != nil {
+		return nil, dbError(ctx, err)
+	}
+	return rslts, nil
+}
+
+// touchTaggedPets increments versions of Pets with the tags, their representation changes.
+func (impl PetStoreRepositoryImpl) touchTaggedPets(ctx context.Context, tagIDs []int64) error {
+	args := []interface{}{}
+	for _, id := range tagIDs {
+		args = append(args, id)
+	}
+
+	SQL := `UPDATE petstore SET version = version + 1
+		WHERE id IN (SELECT pet_id FROM pet_tags WHERE tag_id IN (` + placeholders(len(tagIDs)) + `))`
+	if _, err := conn(ctx, impl.DB).ExecContext(ctx, SQL, args...); err != nil {
+		return dbError(ctx, err)
+	}
+	return nil
+}
+
+// setPetTags replaces tags of Pet in the order, tags no Pet has any more are deleted.
+func (impl PetStoreRepositoryImpl) setPetTags(ctx context.Context, id int64, tags *[]string) error {
+	/*
+		DELETE FROM pet_tags WHERE pet_id = 1;
+		INSERT INTO tags(name) VALUES('foo') ON CONFLICT(name) DO NOTHING;
+		INSERT INTO pet_tags(pet_id, tag_id, position) SELECT 1, id, 0 FROM tags WHERE name = 'foo' ON CONFLICT DO NOTHING;
+		DELETE FROM tags WHERE id NOT IN (SELECT tag_id FROM pet_tags);
+	*/
+
+	db := conn(ctx, impl.DB)
+	if _, err := db.ExecContext(ctx, `DELETE FROM pet_tags WHERE pet_id = ?`, id); err != nil {
+		return dbError(ctx, err)
+	}
+	if tags != nil {
+		for i, tag := range *tags {
+			if _, err := db.ExecContext(ctx, `INSERT INTO tags(name) VALUES(?) ON CONFLICT(name) DO NOTHING`, tag); err != nil {
+				return dbError(ctx, err)
+			}
+			_, err := db.ExecContext(ctx, `INSERT INTO pet_tags(pet_id, tag_id, position)
+				SELECT ?, id, ? FROM tags WHERE name = ? ON CONFLICT DO NOTHING`, id, i, tag)
+			if err != nil {
+				return dbError(ctx, err)
+			}
+		}
+	}
+	return impl.deleteUnusedTags(ctx)
+}
+
+// deleteUnusedTags deletes tags no Pet has, including Pets in the trash.
+func (impl PetStoreRepositoryImpl) deleteUnusedTags(ctx context.Context) error {
+	_, err := conn(ctx, impl.DB).ExecContext(ctx, `DELETE FROM tags WHERE id NOT IN (SELECT tag_id FROM pet_tags)`)
+	if err != nil {
+		return dbError(ctx, err)
+	}
+	return nil
+}
+
+// loadPetTags sets tags of pets in the order, and tag to the first of them.
+// It is shared by the repositories returning Pets.
+func loadPetTags(ctx context.Context, db *sqlx.DB, pets ...*domain.Pet) error {
+	/*
+		SELECT pt.pet_id, t.name FROM pet_tags pt JOIN tags t ON t.id = pt.tag_id
+		WHERE pt.pet_id IN (1, 2) ORDER BY pt.pet_id, pt.position;
+	*/
+
+	byID := map[int64]*domain.Pet{}
+	for _, p := range pets {
+		p.Tag, p.Tags = nil, nil
+		byID[p.Id] = p
+	}
+
+	for start := 0; start < len(pets); start += petTagsChunk {
+		end := start + petTagsChunk
+		if end > len(pets) {
+			end = len(pets)
+		}
+		args := []interface{}{}
+		for _, p := range pets[start:end] {
+			args = append(args, p.Id)
+		}
+
+		rows := []struct {
+			PetID int64  `db:"pet_id"`
+			Name  string `db:"name"`
+		}{}
+		SQL := `SELECT pt.pet_id, t.name FROM pet_tags pt JOIN tags t ON t.id = pt.tag_id
+			WHERE pt.pet_id IN (` + placeholders(len(args)) + `) ORDER BY pt.pet_id, pt.position`
+		if err := conn(ctx, db).SelectContext(ctx, &rows, SQL, args...); err != nil {
+			return dbError(ctx, err)
+		}
+		for _, row := range rows {
+			p := byID[row.PetID]
+			if p.Tags == nil {
+				p.Tags = &[]string{}
+				tag := row.Name
+				p.Tag = &tag
+			}
+			*p.Tags = append(*p.Tags, row.Name)
+		}
+	}
+	return nil
+}
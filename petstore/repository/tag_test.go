@@ -0,0 +1,109 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	// sqlite driver
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/opbls/scapo/petstore/domain"
+	"github.com/opbls/scapo/petstore/migration"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTags(t *testing.T) {
+	ctx := context.Background()
+
+	db, _ := sqlx.Connect("sqlite3", ":memory:")
+	defer db.Close()
+	db.SetMaxOpenConns(1)
+
+	migrator, _ := migration.NewMigrator(db)
+	if _, err := migrator.Up(ctx); err != nil {
+		t.Fatal(err)
+	}
+	repo := NewPetStoreRepository(db)
+
+	//////////////////
+	// TEST DATA
+	//////////////////
+	for _, tags := range [][]string{{"a", "b", "c"}, {"c", "x", "b"}, {"b"}, nil} {
+		p := domain.Pet{}
+		p.Name = "foo"
+		if tags != nil {
+			p.Tags = &tags
+		}
+		if _, err := repo.CreatePet(ctx, &p); err != nil {
+			t.Fatal(err)
+		}
+	}
+	tagsOf := func(id int) []string {
+		p, err := repo.QueryPet(ctx, id)
+		if !assert.NoError(t, err) || p.Tags == nil {
+			return nil
+		}
+		assert.Equal(t, (*p.Tags)[0], *p.Tag)
+		return *p.Tags
+	}
+
+	////////////////////
+	// TEST
+	////////////////////
+	t.Run("SUCCESS_QueryTags", func(t *testing.T) {
+		rslt, err := repo.QueryTags(ctx)
+		assert.NoError(t, err)
+		assert.Equal(t, domain.Tags{{Name: "a", Count: 1}, {Name: "b", Count: 3}, {Name: "c", Count: 2}, {Name: "x", Count: 1}}, *rslt)
+	})
+
+	t.Run("SUCCESS_MergeTags", func(t *testing.T) {
+		rslt, err := repo.MergeTags(ctx, []string{"b", "c"}, "x")
+		assert.NoError(t, err)
+		assert.Equal(t, domain.Tag{Name: "x", Count: 3}, *rslt)
+
+		// the first of the merged tags is kept, into if the Pet has it
+		assert.Equal(t, []string{"a", "x"}, tagsOf(1))
+		assert.Equal(t, []string{"x"}, tagsOf(2))
+		assert.Equal(t, []string{"x"}, tagsOf(3))
+		assert.Nil(t, tagsOf(4))
+
+		p, _ := repo.QueryPet(ctx, 1)
+		assert.Equal(t, int64(2), p.Version)
+	})
+
+	t.Run("SUCCESS_PurgePets_Unused_Tags", func(t *testing.T) {
+		repo.DeletePet(ctx, 1, 0)
+		rslt, _ := repo.QueryTags(ctx)
+		assert.Equal(t, domain.Tags{{Name: "a", Count: 0}, {Name: "x", Count: 2}}, *rslt)
+
+		_, err := repo.PurgePets(ctx, time.Now().Add(time.Second))
+		assert.NoError(t, err)
+		rslt, _ = repo.QueryTags(ctx)
+		assert.Equal(t, domain.Tags{{Name: "x", Count: 2}}, *rslt)
+	})
+
+	// abnormal
+	t.Run("ABNORMAL_MergeTags_NotFound", func(t *testing.T) {
+		rslt, err := repo.MergeTags(ctx, []string{"x", "none"}, "y")
+		assert.NoError(t, err)
+		assert.Nil(t, rslt)
+		assert.Equal(t, []string{"x"}, tagsOf(2))
+	})
+
+	// abnormal
+	t.Run("ABNORMAL_RenameTag_Exists", func(t *testing.T) {
+		p := domain.Pet{}
+		p.Name = "bar"
+		p.Tags = &[]string{"y"}
+		repo.CreatePet(ctx, &p)
+
+		_, err := repo.RenameTag(ctx, "x", "y")
+		assert.True(t, errors.Is(err, domain.Err409Conflict))
+
+		rslt, err := repo.RenameTag(ctx, "none", "z")
+		assert.NoError(t, err)
+		assert.Nil(t, rslt)
+	})
+}
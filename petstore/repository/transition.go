@@ -0,0 +1,45 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/opbls/scapo/petstore/domain"
+)
+
+// QueryPetTransitions return PetTransitions of Pet from db.
+func (impl PetStoreRepositoryImpl) QueryPetTransitions(ctx context.Context, id int) (*domain.PetTransitions, error) {
+	/*
+		SELECT transition, from_status AS "from", to_status AS "to", actor, created_at AS createdat
+		FROM pet_transitions WHERE pet_id = 1 ORDER BY id;
+	*/
+
+	SQL := `SELECT transition, from_status AS "from", to_status AS "to", actor, created_at AS createdat
+		FROM pet_transitions WHERE pet_id = ? ORDER BY id`
+
+	// access db
+	rslts := domain.PetTransitions{}
+	if err := conn(ctx, impl.DB).SelectContext(ctx, &rslts, SQL, id); err != nil {
+		return nil, dbError(ctx, err)
+	}
+
+	return &rslts, nil
+}
+
+// CreatePetTransition insert PetTransition of Pet into db.
+func (impl PetStoreRepositoryImpl) CreatePetTransition(ctx context.Context, id int, t *domain.PetTransition) error {
+	/*
+		INSERT INTO pet_transitions(pet_id, transition, from_status, to_status, actor, created_at)
+		VALUES(1, 'reserve', 'available', 'reserved', 'alice', '2006-01-02 15:04:05');
+	*/
+
+	SQL := `INSERT INTO pet_transitions(pet_id, transition, from_status, to_status, actor, created_at)
+		VALUES(?, ?, ?, ?, ?, ?)`
+
+	// access db
+	_, err := conn(ctx, impl.DB).ExecContext(ctx, SQL, id, t.Transition, t.From, t.To, t.Actor, t.CreatedAt.UTC())
+	if err != nil {
+		return dbError(ctx, err)
+	}
+
+	return nil
+}
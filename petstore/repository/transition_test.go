@@ -0,0 +1,77 @@
+package repository
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	// sqlite driver
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/opbls/scapo/petstore/domain"
+	"github.com/opbls/scapo/petstore/migration"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPetTransitions(t *testing.T) {
+	ctx := context.Background()
+
+	db, _ := sqlx.Connect("sqlite3", ":memory:")
+	defer db.Close()
+	db.SetMaxOpenConns(1)
+
+	migrator, _ := migration.NewMigrator(db)
+	if _, err := migrator.Up(ctx); err != nil {
+		t.Fatal(err)
+	}
+	repo := NewPetStoreRepository(db)
+
+	//////////////////
+	// TEST DATA
+	//////////////////
+	for _, name := range []string{"foo", "bar"} {
+		p := domain.Pet{}
+		p.Name = name
+		if _, err := repo.CreatePet(ctx, &p); err != nil {
+			t.Fatal(err)
+		}
+	}
+	at := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	for _, tr := range []domain.PetTransition{
+		{Transition: "reserve", From: "available", To: "reserved", Actor: "alice", CreatedAt: at},
+		{Transition: "adopt", From: "reserved", To: "adopted", Actor: "bob", CreatedAt: at.Add(time.Hour)},
+	} {
+		if err := repo.CreatePetTransition(ctx, 1, &tr); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	////////////////////
+	// TEST
+	////////////////////
+	t.Run("SUCCESS_QueryPetTransitions", func(t *testing.T) {
+		rslt, err := repo.QueryPetTransitions(ctx, 1)
+		if assert.NoError(t, err) && assert.Len(t, *rslt, 2) {
+			assert.Equal(t, "reserve", (*rslt)[0].Transition)
+			assert.Equal(t, "available", (*rslt)[0].From)
+			assert.Equal(t, "reserved", (*rslt)[0].To)
+			assert.Equal(t, "alice", (*rslt)[0].Actor)
+			assert.True(t, at.Equal((*rslt)[0].CreatedAt))
+			assert.Equal(t, "adopt", (*rslt)[1].Transition)
+		}
+
+		rslt, err = repo.QueryPetTransitions(ctx, 2)
+		assert.NoError(t, err)
+		assert.Empty(t, *rslt)
+	})
+
+	t.Run("SUCCESS_PurgePets", func(t *testing.T) {
+		repo.DeletePet(ctx, 1, 0)
+		_, err := repo.PurgePets(ctx, time.Now().Add(time.Second))
+		assert.NoError(t, err)
+
+		rslt, err := repo.QueryPetTransitions(ctx, 1)
+		assert.NoError(t, err)
+		assert.Empty(t, *rslt)
+	})
+}
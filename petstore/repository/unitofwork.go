@@ -0,0 +1,132 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/jmoiron/sqlx"
+)
+
+type (
+	// UnitOfWork interface.
+	UnitOfWork interface {
+		// Do runs fn in a transaction, repositories called with ctx of fn join the transaction.
+		// It is committed if fn returns nil, rolled back if fn returns error or panics.
+		// Do nested in fn runs in a savepoint, which is rolled back alone.
+		Do(ctx context.Context, fn func(ctx context.Context) error) error
+	}
+
+	// UnitOfWorkImpl struct.
+	UnitOfWorkImpl struct {
+		DB *sqlx.DB
+	}
+
+	// dbConn is *sqlx.DB, or *sqlx.Tx in UnitOfWork.
+	dbConn interface {
+		sqlx.ExtContext
+		sqlx.PreparerContext
+		GetContext(ctx context.Context, dest interface{}, query string, args ...interface{}) error
+		SelectContext(ctx context.Context, dest interface{}, query string, args ...interface{}) error
+		NamedExecContext(ctx context.Context, query string, arg interface{}) (sql.Result, error)
+	}
+
+	// txKey is the context key of unitOfWorkTx.
+	txKey struct{}
+
+	// unitOfWorkTx is the transaction of UnitOfWork.
+	// It is not safe for concurrent use, as *sqlx.Tx.
+	unitOfWorkTx struct {
+		tx    *sqlx.Tx
+		depth int
+	}
+)
+
+// NewUnitOfWork instantiate UnitOfWork.
+func NewUnitOfWork(db *sqlx.DB) UnitOfWork {
+	return &UnitOfWorkImpl{
+		DB: db,
+	}
+}
+
+// Do Impl.
+func (impl UnitOfWorkImpl) Do(ctx context.Context, fn func(ctx context.Context) error) error {
+	return transaction(ctx, impl.DB, fn)
+}
+
+// conn returns the transaction of ctx, db out of UnitOfWork.
+func conn(ctx context.Context, db *sqlx.DB) dbConn {
+	if utx, ok := ctx.Value(txKey{}).(*unitOfWorkTx); ok {
+		return utx.tx
+	}
+	return db
+}
+
+// transaction runs fn in a transaction of db, in a savepoint if ctx is already in a transaction.
+func transaction(ctx context.Context, db *sqlx.DB, fn func(ctx context.Context) error) error {
+	if utx, ok := ctx.Value(txKey{}).(*unitOfWorkTx); ok {
+		return utx.savepoint(ctx, fn)
+	}
+
+	tx, err := db.BeginTxx(ctx, nil)
+	if err != nil {
+		return dbError(ctx, err)
+	}
+	defer func() {
+		if p := recover(); p != nil {
+			tx.Rollback()
+			panic(p)
+		}
+	}()
+
+	if err := fn(context.WithValue(ctx, txKey{}, &unitOfWorkTx{tx: tx})); err != nil {
+		tx.Rollback()
+		return err
+	}
+	if err := tx.Commit(); err != nil {
+		return dbError(ctx, err)
+	}
+	return nil
+}
+
+// savepoint runs fn in a savepoint of utx.
+func (utx *unitOfWorkTx) savepoint(ctx context.Context, fn func(ctx context.Context) error) error {
+	/*
+		SAVEPOINT sp1;
+		RELEASE sp1; -- or ROLLBACK TO sp1; RELEASE sp1;
+	*/
+
+	utx.depth++
+	defer func() { utx.depth-- }()
+	name := fmt.Sprintf("sp%d", utx.depth)
+
+	if _, err := utx.tx.ExecContext(ctx, "SAVEPOINT "+name); err != nil {
+		return dbError(ctx, err)
+	}
+	rollback := func() error {
+		if _, err := utx.tx.ExecContext(ctx, "ROLLBACK TO "+name); err != nil {
+			return err
+		}
+		_, err := utx.tx.ExecContext(ctx, "RELEASE "+name)
+		return err
+	}
+	defer func() {
+		if p := recover(); p != nil {
+			// the outer transaction is rolled back anyway
+			rollback()
+			panic(p)
+		}
+	}()
+
+	if err := fn(ctx); err != nil {
+		if rbErr := rollback(); rbErr != nil {
+			// changes of fn may remain, so the outer transaction must not commit
+			return dbError(ctx, rbErr)
+		}
+		return err
+	}
+	if _, err := utx.tx.ExecContext(ctx, "RELEASE "+name); err != nil {
+		return dbError(ctx, err)
+	}
+	return nil
+}
@@ -0,0 +1,161 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	// sqlite driver
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/opbls/scapo/petstore/domain"
+	"github.com/opbls/scapo/petstore/migration"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUnitOfWork(t *testing.T) {
+	db, _ := sqlx.Connect("sqlite3", ":memory:")
+	defer db.Close()
+	// a transaction left open blocks the following tests
+	db.SetMaxOpenConns(1)
+
+	migrator, _ := migration.NewMigrator(db)
+	if _, err := migrator.Up(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	uow := NewUnitOfWork(db)
+	repo := NewPetStoreRepository(db)
+	idempotency := NewIdempotencyRepository(db)
+	errFailed := errors.New("failed")
+
+	names := func() []string {
+		rslts := []string{}
+		db.Select(&rslts, `SELECT name FROM petstore ORDER BY id`)
+		return rslts
+	}
+	create := func(ctx context.Context, name string) *domain.Pet {
+		p := domain.Pet{}
+		p.Name = name
+		rslt, err := repo.CreatePet(ctx, &p)
+		assert.NoError(t, err)
+		return rslt
+	}
+	reset := func() {
+		db.MustExec(`DELETE FROM petstore`)
+		db.MustExec(`DELETE FROM idempotency_keys`)
+	}
+
+	t.Run("SUCCESS_Commit", func(t *testing.T) {
+		defer reset()
+		err := uow.Do(context.Background(), func(ctx context.Context) error {
+			create(ctx, "foo")
+			create(ctx, "bar")
+			_, err := idempotency.Reserve(ctx, &domain.IdempotencyRecord{
+				Scope: "POST /pets", Key: "abc", RequestHash: "xyz", CreatedAt: time.Now()}, time.Now().Add(-time.Hour), time.Now().Add(-time.Minute))
+			return err
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"foo", "bar"}, names())
+
+		var n int
+		db.Get(&n, `SELECT COUNT(*) FROM idempotency_keys`)
+		assert.Equal(t, 1, n)
+	})
+
+	t.Run("SUCCESS_Savepoint", func(t *testing.T) {
+		defer reset()
+		err := uow.Do(context.Background(), func(ctx context.Context) error {
+			create(ctx, "foo")
+			err := uow.Do(ctx, func(ctx context.Context) error {
+				create(ctx, "bar")
+				return errFailed
+			})
+			assert.Equal(t, errFailed, err)
+			err = uow.Do(ctx, func(ctx context.Context) error {
+				create(ctx, "baz")
+				return nil
+			})
+			assert.NoError(t, err)
+			return nil
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"foo", "baz"}, names())
+	})
+
+	t.Run("SUCCESS_Out_Of_UnitOfWork", func(t *testing.T) {
+		defer reset()
+		create(context.Background(), "foo")
+		assert.Equal(t, []string{"foo"}, names())
+	})
+
+	// abnormal
+	t.Run("ABNORMAL_Error", func(t *testing.T) {
+		defer reset()
+		err := uow.Do(context.Background(), func(ctx context.Context) error {
+			create(ctx, "foo")
+			create(ctx, "bar")
+			return errFailed
+		})
+		assert.Equal(t, errFailed, err)
+		assert.Equal(t, []string{}, names())
+	})
+
+	// abnormal
+	t.Run("ABNORMAL_Error_Repository", func(t *testing.T) {
+		defer reset()
+		err := uow.Do(context.Background(), func(ctx context.Context) error {
+			p := create(ctx, "foo")
+			// the version is 1
+			p.Name = "bar"
+			_, err := repo.UpdatePet(ctx, &domain.VersionedPet{Pet: *p, Version: 2})
+			return err
+		})
+		assert.True(t, errors.Is(err, domain.Err412PreconditionFailed))
+		assert.Equal(t, []string{}, names())
+	})
+
+	// abnormal
+	t.Run("ABNORMAL_Error_Outer", func(t *testing.T) {
+		defer reset()
+		err := uow.Do(context.Background(), func(ctx context.Context) error {
+			create(ctx, "foo")
+			err := uow.Do(ctx, func(ctx context.Context) error {
+				create(ctx, "bar")
+				return nil
+			})
+			assert.NoError(t, err)
+			return errFailed
+		})
+		assert.Equal(t, errFailed, err)
+		assert.Equal(t, []string{}, names())
+	})
+
+	// abnormal
+	t.Run("ABNORMAL_Panic", func(t *testing.T) {
+		defer reset()
+		assert.PanicsWithValue(t, "failed", func() {
+			uow.Do(context.Background(), func(ctx context.Context) error {
+				create(ctx, "foo")
+				panic("failed")
+			})
+		})
+		assert.Equal(t, []string{}, names())
+	})
+
+	// abnormal
+	t.Run("ABNORMAL_Panic_Savepoint", func(t *testing.T) {
+		defer reset()
+		assert.PanicsWithValue(t, "failed", func() {
+			uow.Do(context.Background(), func(ctx context.Context) error {
+				create(ctx, "foo")
+				return uow.Do(ctx, func(ctx context.Context) error {
+					create(ctx, "bar")
+					panic("failed")
+				})
+			})
+		})
+		assert.Equal(t, []string{}, names())
+	})
+}
@@ -0,0 +1,87 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/opbls/scapo/petstore/domain"
+	"github.com/opbls/scapo/petstore/repository"
+)
+
+type (
+	// HealthUsecase interface.
+	HealthUsecase interface {
+		Ready(ctx context.Context) error
+	}
+
+	// HealthUsecaseImpl impl.
+	// The result of the check is reused for TTL so that every request does not ping db.
+	// One check runs at a time with its own Timeout, requests arriving meanwhile wait for it.
+	HealthUsecaseImpl struct {
+		Repository repository.HealthRepository
+		TTL        time.Duration
+		Timeout    time.Duration
+
+		mu        sync.Mutex
+		checkedAt time.Time
+		err       error
+		// checking is closed when the running check finishes, nil if none runs.
+		checking chan struct{}
+	}
+)
+
+// NewHealthUsecase returns Health Usecase.
+func NewHealthUsecase(repo repository.HealthRepository, ttl time.Duration, timeout time.Duration) HealthUsecase {
+	return &HealthUsecaseImpl{
+		Repository: repo,
+		TTL:        ttl,
+		Timeout:    timeout,
+	}
+}
+
+// Ready Impl.
+// ctx bounds only the wait, the check is not cancelled with it.
+func (impl *HealthUsecaseImpl) Ready(ctx context.Context) error {
+	impl.mu.Lock()
+	if !impl.checkedAt.IsZero() && time.Since(impl.checkedAt) < impl.TTL {
+		err := impl.err
+		impl.mu.Unlock()
+		return err
+	}
+	if impl.checking == nil {
+		impl.checking = make(chan struct{})
+		go impl.check(impl.checking)
+	}
+	checking := impl.checking
+	impl.mu.Unlock()
+
+	select {
+	case <-checking:
+	case <-ctx.Done():
+		return domain.Err503ServiceUnavailable.Wrap(ctx.Err())
+	}
+
+	impl.mu.Lock()
+	defer impl.mu.Unlock()
+	return impl.err
+}
+
+// check pings db and closes done, the result is reused unless the ping timed out.
+func (impl *HealthUsecaseImpl) check(done chan struct{}) {
+	ctx, cancel := context.WithTimeout(context.Background(), impl.Timeout)
+	defer cancel()
+	err := impl.Repository.Ping(ctx)
+
+	impl.mu.Lock()
+	defer impl.mu.Unlock()
+	impl.err = err
+	if errors.Is(err, context.DeadlineExceeded) || errors.Is(err, context.Canceled) {
+		impl.checkedAt = time.Time{}
+	} else {
+		impl.checkedAt = time.Now()
+	}
+	impl.checking = nil
+	close(done)
+}
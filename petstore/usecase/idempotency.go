@@ -0,0 +1,87 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"github.com/opbls/scapo/petstore/domain"
+	"github.com/opbls/scapo/petstore/repository"
+)
+
+type (
+	// IdempotencyUsecase interface.
+	IdempotencyUsecase interface {
+		// Begin reserves key for the request of requestHash.
+		// It returns the stored record to replay, or nil when the request should be served.
+		Begin(ctx context.Context, scope string, key string, requestHash string) (*domain.IdempotencyRecord, error)
+		// Complete stores the response to replay.
+		Complete(ctx context.Context, rec *domain.IdempotencyRecord) error
+		// Abort releases key, the response is not stored.
+		Abort(ctx context.Context, scope string, key string) error
+	}
+
+	// IdempotencyUsecaseImpl impl.
+	// Keys are kept for TTL, then the same key is served as a new request.
+	// Keys in progress are leased for Lease, so that a request failed to complete or abort
+	// does not block retries until TTL. No Lease keeps them for TTL.
+	IdempotencyUsecaseImpl struct {
+		Repository repository.IdempotencyRepository
+		TTL        time.Duration
+		Lease      time.Duration
+	}
+)
+
+// NewIdempotencyUsecase returns Idempotency Usecase.
+func NewIdempotencyUsecase(repo repository.IdempotencyRepository, ttl time.Duration, lease time.Duration) IdempotencyUsecase {
+	return &IdempotencyUsecaseImpl{
+		Repository: repo,
+		TTL:        ttl,
+		Lease:      lease,
+	}
+}
+
+// Begin Impl.
+func (impl *IdempotencyUsecaseImpl) Begin(ctx context.Context, scope string, key string, requestHash string) (*domain.IdempotencyRecord, error) {
+	// validate
+	if err := validateIdempotencyKey(key); err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	lease := impl.Lease
+	if lease <= 0 || lease > impl.TTL {
+		lease = impl.TTL
+	}
+	existing, err := impl.Repository.Reserve(ctx, &domain.IdempotencyRecord{
+		Scope:       scope,
+		Key:         key,
+		RequestHash: requestHash,
+		CreatedAt:   now,
+	}, now.Add(-impl.TTL), now.Add(-lease))
+	if err != nil {
+		return nil, err
+	}
+	if existing == nil {
+		return nil, nil
+	}
+
+	if existing.RequestHash != requestHash {
+		return nil, domain.Err422UnprocessableEntity.WithViolations(domain.Violation{
+			Field: "Idempotency-Key", Code: "mismatch", Message: "is already used for another request"})
+	}
+	if !existing.Completed() {
+		return nil, domain.Err409Conflict.WithViolations(domain.Violation{
+			Field: "Idempotency-Key", Code: "in_progress", Message: "request with the key is in progress"})
+	}
+	return existing, nil
+}
+
+// Complete Impl.
+func (impl *IdempotencyUsecaseImpl) Complete(ctx context.Context, rec *domain.IdempotencyRecord) error {
+	return impl.Repository.Complete(ctx, rec)
+}
+
+// Abort Impl.
+func (impl *IdempotencyUsecaseImpl) Abort(ctx context.Context, scope string, key string) error {
+	return impl.Repository.Release(ctx, scope, key)
+}
@@ -0,0 +1,194 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/opbls/scapo/petstore/domain"
+	"github.com/opbls/scapo/petstore/repository"
+)
+
+type (
+	// OwnerUsecase interface.
+	OwnerUsecase interface {
+		// FindOwners returns up to limit Owners after afterID.
+		FindOwners(ctx context.Context, afterID int64, limit int) (*domain.Owners, error)
+		AddOwner(ctx context.Context, o *domain.Owner) (*domain.Owner, error)
+		// FindOwnerById, UpdateOwner and FindOwnerPets return nil if Owner does not exist.
+		FindOwnerById(ctx context.Context, id int) (*domain.Owner, error)
+		UpdateOwner(ctx context.Context, id int, o *domain.Owner) (*domain.Owner, error)
+		// DeleteOwner returns Err409Conflict while Owner has Pets, including Pets in the trash.
+		// Adoptions of Owner are deleted with it.
+		DeleteOwner(ctx context.Context, id int) (int, error)
+		FindOwnerPets(ctx context.Context, id int) (*domain.Pets, error)
+
+		// AdoptPet makes the adopt transition of the reserved Pet and links it to Owner in a transaction.
+		// Err422UnprocessableEntity is returned if Pet or Owner does not exist.
+		AdoptPet(ctx context.Context, a *domain.Adoption) (*domain.Adoption, error)
+		// FindAdoptionById returns nil if Adoption does not exist.
+		FindAdoptionById(ctx context.Context, id int) (*domain.Adoption, error)
+	}
+
+	// OwnerUsecaseImpl impl.
+	// Pets are read and transitioned by Pets in the same UnitOfWork.
+	OwnerUsecaseImpl struct {
+		UnitOfWork repository.UnitOfWork
+		Repository repository.OwnerRepository
+		Pets       repository.PetStoreRepository
+	}
+)
+
+// NewOwnerUsecase returns Owner Usecase.
+func NewOwnerUsecase(uow repository.UnitOfWork, repo repository.OwnerRepository, pets repository.PetStoreRepository) OwnerUsecase {
+	return &OwnerUsecaseImpl{
+		UnitOfWork: uow,
+		Repository: repo,
+		Pets:       pets,
+	}
+}
+
+// FindOwners Impl.
+func (impl *OwnerUsecaseImpl) FindOwners(ctx context.Context, afterID int64, limit int) (*domain.Owners, error) {
+	return impl.Repository.QueryOwners(ctx, afterID, limit)
+}
+
+// AddOwner Impl.
+func (impl *OwnerUsecaseImpl) AddOwner(ctx context.Context, o *domain.Owner) (*domain.Owner, error) {
+	// validate
+	if err := validateOwner(*o); err != nil {
+		return nil, err
+	}
+
+	return impl.Repository.CreateOwner(ctx, o)
+}
+
+// FindOwnerById Impl.
+func (impl *OwnerUsecaseImpl) FindOwnerById(ctx context.Context, id int) (*domain.Owner, error) {
+	// validate
+	if err := validatePathParamOwnerID(id); err != nil {
+		return nil, err
+	}
+
+	return impl.Repository.QueryOwner(ctx, id)
+}
+
+// UpdateOwner Impl.
+func (impl *OwnerUsecaseImpl) UpdateOwner(ctx context.Context, id int, o *domain.Owner) (*domain.Owner, error) {
+	// validate
+	if err := validatePathParamOwnerID(id); err != nil {
+		return nil, err
+	}
+	if err := validateOwner(*o); err != nil {
+		return nil, err
+	}
+
+	o.Id = int64(id)
+	i, err := impl.Repository.UpdateOwner(ctx, o)
+	if err != nil {
+		return nil, err
+	}
+
+	// act as not found
+	if i == 0 {
+		return nil, nil
+	}
+
+	return o, nil
+}
+
+// DeleteOwner Impl.
+func (impl *OwnerUsecaseImpl) DeleteOwner(ctx context.Context, id int) (int, error) {
+	// validate
+	if err := validatePathParamOwnerID(id); err != nil {
+		return -1, err
+	}
+
+	notaffected := -1
+	var rslt int
+	err := impl.UnitOfWork.Do(ctx, func(ctx context.Context) error {
+		// pets in the trash are counted, they may be restored
+		n, err := impl.Repository.CountOwnerPets(ctx, id)
+		if err != nil {
+			return err
+		}
+		if n > 0 {
+			return domain.Err409Conflict.WithMessage("Owner With Pets Can Not Be Deleted")
+		}
+
+		rslt, err = impl.Repository.DeleteOwner(ctx, id)
+		return err
+	})
+	if err != nil {
+		return notaffected, err
+	}
+
+	return rslt, nil
+}
+
+// FindOwnerPets Impl.
+func (impl *OwnerUsecaseImpl) FindOwnerPets(ctx context.Context, id int) (*domain.Pets, error) {
+	// validate
+	if err := validatePathParamOwnerID(id); err != nil {
+		return nil, err
+	}
+
+	var rslt *domain.Pets
+	err := impl.UnitOfWork.Do(ctx, func(ctx context.Context) error {
+		owner, err := impl.Repository.QueryOwner(ctx, id)
+		if err != nil || owner == nil {
+			return err
+		}
+
+		rslt, err = impl.Repository.QueryOwnerPets(ctx, id)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return rslt, nil
+}
+
+// AdoptPet Impl.
+func (impl *OwnerUsecaseImpl) AdoptPet(ctx context.Context, a *domain.Adoption) (*domain.Adoption, error) {
+	// validate
+	if err := validateAdoption(*a); err != nil {
+		return nil, err
+	}
+
+	var rslt *domain.Adoption
+	err := impl.UnitOfWork.Do(ctx, func(ctx context.Context) error {
+		owner, err := impl.Repository.QueryOwner(ctx, int(a.OwnerId))
+		if err != nil {
+			return err
+		}
+		if owner == nil {
+			return domain.Err422UnprocessableEntity.WithViolations(domain.Violation{Field: "owner_id", Code: "not_found", Message: "owner does not exist"})
+		}
+
+		pet, err := transitionPet(ctx, impl.Pets, int(a.PetId), domain.PetTransitionAdopt, a.Actor, 0)
+		if err != nil {
+			return err
+		}
+		if pet == nil {
+			return domain.Err422UnprocessableEntity.WithViolations(domain.Violation{Field: "pet_id", Code: "not_found", Message: "pet does not exist"})
+		}
+
+		rslt, err = impl.Repository.CreateAdoption(ctx, a)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return rslt, nil
+}
+
+// FindAdoptionById Impl.
+func (impl *OwnerUsecaseImpl) FindAdoptionById(ctx context.Context, id int) (*domain.Adoption, error) {
+	// validate
+	if err := validatePathParamAdoptionID(id); err != nil {
+		return nil, err
+	}
+
+	return impl.Repository.QueryAdoption(ctx, id)
+}
@@ -0,0 +1,49 @@
+package usecase
+
+import (
+	"strings"
+
+	validation "github.com/go-ozzo/ozzo-validation"
+	"github.com/go-ozzo/ozzo-validation/is"
+	"github.com/opbls/scapo/petstore/domain"
+)
+
+// validatePathParamOwnerID validate Request Parameter OwnerID.
+func validatePathParamOwnerID(id int) error {
+	// open api
+	if id < 0 {
+		return domain.Err400BadRequest.WithViolations(domain.Violation{Field: "id", Code: "min", Message: "must be no less than 0"})
+	}
+	return nil
+}
+
+// validatePathParamAdoptionID validate Request Parameter AdoptionID.
+func validatePathParamAdoptionID(id int) error {
+	// open api
+	if id < 0 {
+		return domain.Err400BadRequest.WithViolations(domain.Violation{Field: "id", Code: "min", Message: "must be no less than 0"})
+	}
+	return nil
+}
+
+// validateOwner validate Owner.
+func validateOwner(o domain.Owner) error {
+	err := validation.ValidateStruct(&o,
+		validation.Field(&o.Name, validation.Required, validation.Length(1, 100)),
+		validation.Field(&o.Email, validation.Required, validation.Length(0, 254), is.Email),
+		validation.Field(&o.Phone, validation.Length(0, 30)),
+	)
+	return domain.ViolationsOf(err)
+}
+
+// validateAdoption validate Adoption.
+func validateAdoption(a domain.Adoption) error {
+	// open api
+	if a.PetId < 0 {
+		return domain.Err400BadRequest.WithViolations(domain.Violation{Field: "pet_id", Code: "min", Message: "must be no less than 0"})
+	}
+	if a.OwnerId < 0 {
+		return domain.Err400BadRequest.WithViolations(domain.Violation{Field: "owner_id", Code: "min", Message: "must be no less than 0"})
+	}
+	return validatePetTransition(domain.PetTransitionAdopt, strings.TrimSpace(a.Actor))
+}
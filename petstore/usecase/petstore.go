@@ -1,6 +1,11 @@
 package usecase
 
 import (
+	"context"
+	"encoding/json"
+	"errors"
+	"time"
+
 	// sqlite driver
 	_ "github.com/mattn/go-sqlite3"
 	"github.com/opbls/scapo/petstore/domain"
@@ -10,51 +15,613 @@ import (
 type (
 	// PetStoreUsecase interface.
 	PetStoreUsecase interface {
-		FindPets(condition *domain.QueryCondition) (*domain.Pets, error)
-		AddPet(np *domain.Pet) (*domain.Pet, error)
-		DeletePet(id int) (int, error)
-		FindPetById(id int) (*domain.Pet, error)
+		FindPets(ctx context.Context, query *domain.PetQuery) (*domain.Pets, error)
+		AddPet(ctx context.Context, np *domain.Pet) (*domain.Pet, error)
+		// DeletePet, UpdatePet and PatchPet apply to Pet of version, any version if 0.
+		DeletePet(ctx context.Context, id int, version int64) (int, error)
+		FindPetById(ctx context.Context, id int) (*domain.VersionedPet, error)
+		UpdatePet(ctx context.Context, id int, p *domain.Pet, version int64) (*domain.VersionedPet, error)
+		// UpdatePetV1 updates as UpdatePet, keeping fields added in v2 which are not in fields of the request body.
+		UpdatePetV1(ctx context.Context, id int, p *domain.Pet, version int64, fields []string) (*domain.VersionedPet, error)
+		PatchPet(ctx context.Context, id int, patch domain.PetPatch, version int64) (*domain.VersionedPet, error)
+		// RestorePet restores deleted Pet, nil if not deleted.
+		RestorePet(ctx context.Context, id int) (*domain.VersionedPet, error)
+		// PurgePets deletes Pets deleted before deletedBefore, they can not be restored any more.
+		PurgePets(ctx context.Context, deletedBefore time.Time) (int, error)
+		// AddPets and DeletePets apply items in a transaction, failed items are reported by mode.
+		AddPets(ctx context.Context, nps []domain.Pet, mode domain.BatchMode) (*domain.BatchResult, error)
+		DeletePets(ctx context.Context, ids []int, mode domain.BatchMode) (*domain.BatchResult, error)
+
+		// TransitionPet changes the status of Pet by transition, recorded with actor.
+		// Err409TransitionNotAllowed is returned with the allowed transitions if not allowed from the status.
+		TransitionPet(ctx context.Context, id int, transition string, actor string, version int64) (*domain.VersionedPet, error)
+		// FindPetTransitions returns nil if Pet does not exist.
+		FindPetTransitions(ctx context.Context, id int) (*domain.PetTransitions, error)
+
+		// SearchPets returns Pets matching all terms of search, ranked by relevance.
+		SearchPets(ctx context.Context, search *domain.PetSearch) (*domain.PetSearchHits, error)
+
+		FindTags(ctx context.Context) (*domain.Tags, error)
+		// RenameTag and MergeTags return nil if the tag to rename or merge does not exist.
+		RenameTag(ctx context.Context, from string, to string) (*domain.Tag, error)
+		MergeTags(ctx context.Context, from []string, into string) (*domain.Tag, error)
 	}
 
 	// PetStoreUsecaseImpl impl.
+	// Writes of more than one statement run in UnitOfWork.
 	PetStoreUsecaseImpl struct {
+		UnitOfWork repository.UnitOfWork
 		Repository repository.PetStoreRepository
 	}
 )
 
+// errBatchFailed rolls back the transaction of the atomic batch with failed items.
+var errBatchFailed = errors.New("batch failed")
+
+// patchRetries is how many times PatchPet without version applies the patch again after a concurrent write.
+const patchRetries = 3
+
 // NewPetStoreUsecase returns Petstore Usecase.
-func NewPetStoreUsecase(repo repository.PetStoreRepository) PetStoreUsecase {
+func NewPetStoreUsecase(uow repository.UnitOfWork, repo repository.PetStoreRepository) PetStoreUsecase {
 	return &PetStoreUsecaseImpl{
+		UnitOfWork: uow,
 		Repository: repo,
 	}
 }
 
 // FindPets Impl.
-func (impl *PetStoreUsecaseImpl) FindPets(condition *domain.QueryCondition) (*domain.Pets, error) {
-	return impl.Repository.QueryPets(condition)
+func (impl *PetStoreUsecaseImpl) FindPets(ctx context.Context, query *domain.PetQuery) (*domain.Pets, error) {
+	return impl.Repository.QueryPets(ctx, query)
 }
 
 // AddPet Impl.
-func (impl *PetStoreUsecaseImpl) AddPet(np *domain.Pet) (*domain.Pet, error) {
-	return impl.Repository.CreatePet(np)
+func (impl *PetStoreUsecaseImpl) AddPet(ctx context.Context, np *domain.Pet) (*domain.Pet, error) {
+	// validate
+	if err := validateNewPet(*np); err != nil {
+		return nil, err
+	}
+
+	if err := normalizePet(np); err != nil {
+		return nil, err
+	}
+
+	return impl.Repository.CreatePet(ctx, np)
 }
 
 // DeletePet Impl
-func (impl *PetStoreUsecaseImpl) DeletePet(id int) (int, error) {
+func (impl *PetStoreUsecaseImpl) DeletePet(ctx context.Context, id int, version int64) (int, error) {
 	// validate
 	if err := validatePathParamPetID(id); err != nil {
-		return -1, domain.Err400BadRequest
+		return -1, err
 	}
 
-	return impl.Repository.DeletePet(id)
+	return impl.deletePet(ctx, id, version)
+}
+
+// deletePet deletes Pet unless it is reserved.
+func (impl *PetStoreUsecaseImpl) deletePet(ctx context.Context, id int, version int64) (int, error) {
+	notaffected := -1
+	var rslt int
+	err := impl.UnitOfWork.Do(ctx, func(ctx context.Context) error {
+		current, err := impl.Repository.QueryPet(ctx, id)
+		if err != nil || current == nil {
+			return err
+		}
+		if statusOf(&current.Pet) == domain.PetStatusReserved {
+			return domain.Err409Conflict.WithMessage("Reserved Pet Can Not Be Deleted").
+				WithAllowedTransitions(domain.AllowedTransitions(domain.PetStatusReserved)...)
+		}
+
+		rslt, err = impl.Repository.DeletePet(ctx, id, version)
+		return err
+	})
+	if err != nil {
+		return notaffected, err
+	}
+
+	return rslt, nil
 }
 
 // FindPetById Impl.
-func (impl *PetStoreUsecaseImpl) FindPetById(id int) (*domain.Pet, error) {
+func (impl *PetStoreUsecaseImpl) FindPetById(ctx context.Context, id int) (*domain.VersionedPet, error) {
+	// validate
+	if err := validatePathParamPetID(id); err != nil {
+		return nil, err
+	}
+
+	return impl.Repository.QueryPet(ctx, id)
+}
+
+// UpdatePet Impl.
+func (impl *PetStoreUsecaseImpl) UpdatePet(ctx context.Context, id int, p *domain.Pet, version int64) (*domain.VersionedPet, error) {
+	return impl.updatePet(ctx, id, p, version, nil)
+}
+
+// UpdatePetV1 Impl.
+// v1 clients do not see fields added in v2, so they are kept unless sent, the same as PatchPet.
+func (impl *PetStoreUsecaseImpl) UpdatePetV1(ctx context.Context, id int, p *domain.Pet, version int64, fields []string) (*domain.VersionedPet, error) {
+	keep := map[string]bool{}
+	for _, field := range petV2Fields {
+		keep[field] = true
+	}
+	for _, field := range fields {
+		delete(keep, field)
+	}
+	return impl.updatePet(ctx, id, p, version, keep)
+}
+
+// petV2Fields are JSON fields of Pet added in v2.
+var petV2Fields = []string{"tags", "species", "breed", "birth_date", "sex", "attributes"}
+
+// updatePet replaces Pet by p, fields in keep are kept from the current Pet.
+// Tags in keep are kept if the tag of p is the current tag, otherwise replaced by the tag.
+func (impl *PetStoreUsecaseImpl) updatePet(ctx context.Context, id int, p *domain.Pet, version int64, keep map[string]bool) (*domain.VersionedPet, error) {
+	// validate
+	if err := validatePathParamPetID(id); err != nil {
+		return nil, err
+	}
+	if err := validatePet(*p); err != nil {
+		return nil, err
+	}
+
+	// status is kept, it changes only by TransitionPet
+	var rslt *domain.VersionedPet
+	err := impl.UnitOfWork.Do(ctx, func(ctx context.Context) error {
+		current, err := impl.Repository.QueryPet(ctx, id)
+		if err != nil || current == nil {
+			return err
+		}
+		status := statusOf(&current.Pet)
+		if p.Status != nil && *p.Status != status {
+			return domain.Err409TransitionNotAllowed.WithAllowedTransitions(domain.AllowedTransitions(status)...).
+				WithViolations(domain.Violation{Field: "status", Code: "transition", Message: "changes only by transitions"})
+		}
+		p.Status = &status
+		if keep["species"] {
+			p.Species = current.Species
+		}
+		if keep["breed"] {
+			p.Breed = current.Breed
+		}
+		if keep["birth_date"] {
+			p.BirthDate = current.BirthDate
+		}
+		if keep["sex"] {
+			p.Sex = current.Sex
+		}
+		if keep["attributes"] {
+			p.Attributes = current.Attributes
+		}
+		if keep["tags"] {
+			p.Tags = nil
+			if p.Tag != nil && current.Tag != nil && *p.Tag == *current.Tag {
+				p.Tags = current.Tags
+			}
+		}
+
+		if err := normalizePet(p); err != nil {
+			return err
+		}
+
+		p.Id = int64(id)
+		updated := &domain.VersionedPet{Pet: *p, Version: version}
+		i, err := impl.Repository.UpdatePet(ctx, updated)
+		if err != nil {
+			return err
+		}
+
+		// act as not found
+		if i > 0 {
+			rslt = updated
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return rslt, nil
+}
+
+// PatchPet Impl.
+// Without version, the patch is applied again to the latest Pet if another write lands meanwhile.
+func (impl *PetStoreUsecaseImpl) PatchPet(ctx context.Context, id int, patch domain.PetPatch, version int64) (*domain.VersionedPet, error) {
+	// validate
+	if err := validatePathParamPetID(id); err != nil {
+		return nil, err
+	}
+
+	for attempt := 0; ; attempt++ {
+		rslt, err := impl.patchPet(ctx, id, patch, version)
+		if version != 0 || !errors.Is(err, domain.Err412PreconditionFailed) {
+			return rslt, err
+		}
+		// the client sent no precondition
+		if attempt >= patchRetries {
+			return nil, domain.Err409Conflict.WithMessage("Pet Is Modified Concurrently, Retry The Request")
+		}
+	}
+}
+
+// patchPet reads and updates the same version of Pet, Err412PreconditionFailed if it is not of version.
+func (impl *PetStoreUsecaseImpl) patchPet(ctx context.Context, id int, patch domain.PetPatch, version int64) (*domain.VersionedPet, error) {
+	var rslt *domain.VersionedPet
+	err := impl.UnitOfWork.Do(ctx, func(ctx context.Context) error {
+		current, err := impl.Repository.QueryPet(ctx, id)
+		if err != nil {
+			return err
+		}
+		if current == nil {
+			return nil
+		}
+		if version != 0 && current.Version != version {
+			return domain.Err412PreconditionFailed
+		}
+
+		p, err := applyPetPatch(&current.Pet, patch)
+		if err != nil {
+			return err
+		}
+		// validated by UpdatePet after patch applied
+		rslt, err = impl.UpdatePet(ctx, id, p, current.Version)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return rslt, nil
+}
+
+// RestorePet Impl.
+func (impl *PetStoreUsecaseImpl) RestorePet(ctx context.Context, id int) (*domain.VersionedPet, error) {
+	// validate
+	if err := validatePathParamPetID(id); err != nil {
+		return nil, err
+	}
+
+	return impl.Repository.RestorePet(ctx, id)
+}
+
+// PurgePets Impl.
+func (impl *PetStoreUsecaseImpl) PurgePets(ctx context.Context, deletedBefore time.Time) (int, error) {
+	return impl.Repository.PurgePets(ctx, deletedBefore)
+}
+
+// AddPets Impl.
+// Every item is validated before any is created.
+func (impl *PetStoreUsecaseImpl) AddPets(ctx context.Context, nps []domain.Pet, mode domain.BatchMode) (*domain.BatchResult, error) {
+	rslt := &domain.BatchResult{Items: make([]domain.BatchItem, len(nps))}
+	for i := range nps {
+		rslt.Items[i].Err = validateNewPet(nps[i])
+		if rslt.Items[i].Err == nil {
+			rslt.Items[i].Err = normalizePet(&nps[i])
+		}
+	}
+	if mode == domain.BatchAtomic && rslt.Failed() {
+		return rollbackBatch(rslt), nil
+	}
+
+	return impl.applyBatch(ctx, rslt, mode, func(ctx context.Context, i int) error {
+		p, err := impl.Repository.CreatePet(ctx, &nps[i])
+		if err != nil {
+			return err
+		}
+		rslt.Items[i].ID = p.Id
+		rslt.Items[i].Pet = p
+		return nil
+	})
+}
+
+// DeletePets Impl.
+// Duplicate ids are deleted once.
+func (impl *PetStoreUsecaseImpl) DeletePets(ctx context.Context, ids []int, mode domain.BatchMode) (*domain.BatchResult, error) {
+	ids = uniqueInts(ids)
+	rslt := &domain.BatchResult{Items: make([]domain.BatchItem, len(ids))}
+	for i, id := range ids {
+		rslt.Items[i].ID = int64(id)
+		rslt.Items[i].Err = validatePathParamPetID(id)
+	}
+	if mode == domain.BatchAtomic && rslt.Failed() {
+		return rollbackBatch(rslt), nil
+	}
+
+	return impl.applyBatch(ctx, rslt, mode, func(ctx context.Context, i int) error {
+		n, err := impl.deletePet(ctx, ids[i], 0)
+		if err != nil {
+			return err
+		}
+		if n == 0 {
+			rslt.Items[i].Err = domain.Err404NotFound
+		}
+		return nil
+	})
+}
+
+// TransitionPet Impl.
+func (impl *PetStoreUsecaseImpl) TransitionPet(ctx context.Context, id int, transition string, actor string, version int64) (*domain.VersionedPet, error) {
+	// validate
+	if err := validatePathParamPetID(id); err != nil {
+		return nil, err
+	}
+	if err := validatePetTransition(transition, actor); err != nil {
+		return nil, err
+	}
+
+	var rslt *domain.VersionedPet
+	err := impl.UnitOfWork.Do(ctx, func(ctx context.Context) error {
+		var err error
+		rslt, err = transitionPet(ctx, impl.Repository, id, transition, actor, version)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return rslt, nil
+}
+
+// transitionPet reads and updates the same version of Pet by transition and records it, nil if Pet does not exist.
+// It must run in UnitOfWork.
+func transitionPet(ctx context.Context, repo repository.PetStoreRepository, id int, transition string, actor string, version int64) (*domain.VersionedPet, error) {
+	current, err := repo.QueryPet(ctx, id)
+	if err != nil || current == nil {
+		return nil, err
+	}
+	if version != 0 && current.Version != version {
+		return nil, domain.Err412PreconditionFailed
+	}
+
+	from := statusOf(&current.Pet)
+	to, err := domain.TransitionTo(from, transition)
+	if err != nil {
+		return nil, err
+	}
+
+	current.Status = &to
+	i, err := repo.UpdatePet(ctx, current)
+	if err != nil || i == 0 {
+		return nil, err
+	}
+	t := domain.PetTransition{Transition: transition, From: from, To: to, Actor: actor, CreatedAt: time.Now()}
+	if err := repo.CreatePetTransition(ctx, id, &t); err != nil {
+		return nil, err
+	}
+
+	return current, nil
+}
+
+// FindPetTransitions Impl.
+func (impl *PetStoreUsecaseImpl) FindPetTransitions(ctx context.Context, id int) (*domain.PetTransitions, error) {
 	// validate
 	if err := validatePathParamPetID(id); err != nil {
-		return nil, domain.Err400BadRequest
+		return nil, err
+	}
+
+	var rslt *domain.PetTransitions
+	err := impl.UnitOfWork.Do(ctx, func(ctx context.Context) error {
+		current, err := impl.Repository.QueryPet(ctx, id)
+		if err != nil || current == nil {
+			return err
+		}
+
+		rslt, err = impl.Repository.QueryPetTransitions(ctx, id)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return rslt, nil
+}
+
+// SearchPets Impl.
+func (impl *PetStoreUsecaseImpl) SearchPets(ctx context.Context, search *domain.PetSearch) (*domain.PetSearchHits, error) {
+	// validate
+	if err := validatePetSearch(*search); err != nil {
+		return nil, err
 	}
 
-	return impl.Repository.QueryPet(id)
+	return impl.Repository.SearchPets(ctx, search)
+}
+
+// FindTags Impl.
+func (impl *PetStoreUsecaseImpl) FindTags(ctx context.Context) (*domain.Tags, error) {
+	return impl.Repository.QueryTags(ctx)
+}
+
+// RenameTag Impl.
+func (impl *PetStoreUsecaseImpl) RenameTag(ctx context.Context, from string, to string) (*domain.Tag, error) {
+	// validate
+	if err := validateTagRename(from, to); err != nil {
+		return nil, err
+	}
+
+	return impl.Repository.RenameTag(ctx, from, to)
+}
+
+// MergeTags Impl.
+func (impl *PetStoreUsecaseImpl) MergeTags(ctx context.Context, from []string, into string) (*domain.Tag, error) {
+	from = domain.UniqueStrings(from)
+
+	// validate
+	if err := validateTagMerge(from, into); err != nil {
+		return nil, err
+	}
+
+	return impl.Repository.MergeTags(ctx, from, into)
+}
+
+// applyBatch applies items of rslt not failed yet by apply in a transaction, each item in a savepoint.
+// Client errors of apply fail the item, other errors fail the whole batch.
+// Items failed by apply are rolled back in atomic mode.
+func (impl *PetStoreUsecaseImpl) applyBatch(ctx context.Context, rslt *domain.BatchResult, mode domain.BatchMode, apply func(ctx context.Context, i int) error) (*domain.BatchResult, error) {
+	err := impl.UnitOfWork.Do(ctx, func(ctx context.Context) error {
+		for i := range rslt.Items {
+			if rslt.Items[i].Err != nil {
+				continue
+			}
+			err := impl.UnitOfWork.Do(ctx, func(ctx context.Context) error {
+				return apply(ctx, i)
+			})
+			if isItemError(err) {
+				rslt.Items[i].Err = err
+				continue
+			}
+			if err != nil {
+				return err
+			}
+		}
+		if mode == domain.BatchAtomic && rslt.Failed() {
+			return errBatchFailed
+		}
+		return nil
+	})
+	if errors.Is(err, errBatchFailed) {
+		return rollbackBatch(rslt), nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	rslt.Committed = true
+	return rslt, nil
+}
+
+// isItemError reports whether err is caused by the item, not by the database or the request.
+func isItemError(err error) bool {
+	var e *domain.Error
+	if !errors.As(err, &e) {
+		return false
+	}
+	return e.Status >= 400 && e.Status < 500 && e.Status != domain.Err499ClientClosedRequest.Status
+}
+
+// rollbackBatch marks items not failed as rolled back.
+func rollbackBatch(rslt *domain.BatchResult) *domain.BatchResult {
+	for i := range rslt.Items {
+		if rslt.Items[i].Err != nil {
+			continue
+		}
+		rslt.Items[i].Err = domain.Err424FailedDependency
+		if rslt.Items[i].Pet != nil {
+			// created in the transaction
+			rslt.Items[i].ID = 0
+			rslt.Items[i].Pet = nil
+		}
+	}
+	rslt.Committed = false
+	return rslt
+}
+
+// applyPetPatch returns a copy of Pet with JSON Merge Patch applied.
+func applyPetPatch(p *domain.Pet, patch domain.PetPatch) (*domain.Pet, error) {
+	b, err := json.Marshal(p)
+	if err != nil {
+		return nil, domain.Err500InternalServerError.Wrap(err)
+	}
+	doc := map[string]interface{}{}
+	if err := json.Unmarshal(b, &doc); err != nil {
+		return nil, domain.Err500InternalServerError.Wrap(err)
+	}
+	// tag is the first of tags, the one not patched follows the other
+	if _, ok := patch["tags"]; ok {
+		delete(doc, "tag")
+	} else if _, ok := patch["tag"]; ok {
+		delete(doc, "tags")
+	}
+
+	b, err = json.Marshal(mergePatch(doc, patch))
+	if err != nil {
+		return nil, domain.Err500InternalServerError.Wrap(err)
+	}
+	rslt := domain.Pet{}
+	if err := json.Unmarshal(b, &rslt); err != nil {
+		return nil, domain.Err400BadRequest.Wrap(err)
+	}
+	// id is not patchable
+	rslt.Id = p.Id
+
+	return &rslt, nil
+}
+
+// statusOf returns status of p, available if absent.
+func statusOf(p *domain.Pet) string {
+	if p.Status == nil {
+		return domain.PetStatusAvailable
+	}
+	return *p.Status
+}
+
+// normalizePet sets status to available if absent and normalizes tags.
+func normalizePet(p *domain.Pet) error {
+	if p.Status == nil {
+		status := domain.PetStatusAvailable
+		p.Status = &status
+	}
+	return normalizePetTags(p)
+}
+
+// normalizePetTags sets tags to tag if tags are absent, otherwise tag to the first of tags.
+// Duplicate and empty tags are removed, an empty tag is no tag.
+func normalizePetTags(p *domain.Pet) error {
+	if p.Tag != nil && *p.Tag == "" {
+		p.Tag = nil
+	}
+	if p.Tags == nil {
+		if p.Tag != nil {
+			p.Tags = &[]string{*p.Tag}
+		}
+		return nil
+	}
+
+	tags := []string{}
+	for _, t := range domain.UniqueStrings(*p.Tags) {
+		if t != "" {
+			tags = append(tags, t)
+		}
+	}
+	if p.Tag != nil && !domain.ContainsString(tags, *p.Tag) {
+		return domain.Err400BadRequest.WithViolations(domain.Violation{Field: "tag", Code: "conflict", Message: "must be one of tags"})
+	}
+	if len(tags) == 0 {
+		p.Tag, p.Tags = nil, nil
+		return nil
+	}
+	first := tags[0]
+	p.Tag, p.Tags = &first, &tags
+	return nil
+}
+
+// uniqueInts returns ns without duplicates in the order.
+func uniqueInts(ns []int) []int {
+	rslts := []int{}
+	seen := map[int]bool{}
+	for _, n := range ns {
+		if !seen[n] {
+			seen[n] = true
+			rslts = append(rslts, n)
+		}
+	}
+	return rslts
+}
+
+// mergePatch apply patch to target as described in RFC 7386.
+func mergePatch(target map[string]interface{}, patch map[string]interface{}) map[string]interface{} {
+	for k, v := range patch {
+		if v == nil {
+			delete(target, k)
+			continue
+		}
+		vm, ok := v.(map[string]interface{})
+		if !ok {
+			target[k] = v
+			continue
+		}
+		tm, ok := target[k].(map[string]interface{})
+		if !ok {
+			tm = map[string]interface{}{}
+		}
+		target[k] = mergePatch(tm, vm)
+	}
+	return target
 }
@@ -1,6 +1,14 @@
 package usecase
 
 import (
+	"encoding/json"
+	"errors"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	openapi_types "github.com/deepmap/oapi-codegen/pkg/types"
+	validation "github.com/go-ozzo/ozzo-validation"
 	"github.com/opbls/scapo/petstore/domain"
 )
 
@@ -8,7 +16,133 @@ import (
 func validatePathParamPetID(id int) error {
 	// open api
 	if id < 0 {
-		return domain.Err400BadRequest
+		return domain.Err400BadRequest.WithViolations(domain.Violation{Field: "id", Code: "min", Message: "must be no less than 0"})
+	}
+	return nil
+}
+
+// validatePet validate Pet after patch applied.
+func validatePet(p domain.Pet) error {
+	err := validation.ValidateStruct(&p,
+		validation.Field(&p.Name, validation.Required),
+		validation.Field(&p.Species, validation.Length(0, 50)),
+		validation.Field(&p.Breed, validation.Length(0, 50)),
+		validation.Field(&p.Sex, validation.In(anySlice(domain.PetSexes)...)),
+		validation.Field(&p.Status, validation.In(anySlice(domain.PetStatuses)...)),
+		validation.Field(&p.BirthDate, validation.By(notFutureDate)),
+		validation.Field(&p.Attributes, validation.By(validAttributes)),
+	)
+	return domain.ViolationsOf(err)
+}
+
+// validateNewPet validate Pet to create, the status is one of PetInitialStatuses.
+func validateNewPet(p domain.Pet) error {
+	if err := validatePet(p); err != nil {
+		return err
+	}
+	if p.Status != nil && !domain.ContainsString(domain.PetInitialStatuses, *p.Status) {
+		return domain.Err400BadRequest.WithViolations(domain.Violation{
+			Field: "status", Code: "invalid", Message: "must be available or on_hold, others are reached by transitions"})
+	}
+	return nil
+}
+
+// validatePetTransition validate transition and actor.
+func validatePetTransition(transition string, actor string) error {
+	// open api
+	if !domain.ContainsString(domain.PetTransitionNames(), transition) {
+		return domain.Err400BadRequest.WithViolations(domain.Violation{Field: "transition", Code: "invalid", Message: "must be a valid value"})
+	}
+	if strings.TrimSpace(actor) == "" {
+		return domain.Err400BadRequest.WithViolations(domain.Violation{Field: "actor", Code: "required", Message: "cannot be blank"})
+	}
+	return nil
+}
+
+// notFutureDate validates *openapi_types.Date is not after today in any time zone, UTC+14 at most.
+func notFutureDate(value interface{}) error {
+	d, _ := value.(*openapi_types.Date)
+	if d != nil && d.After(time.Now().UTC().Add(14*time.Hour)) {
+		return errors.New("must not be in the future")
+	}
+	return nil
+}
+
+// validAttributes validates *map[string]interface{} of Pet attributes,
+// up to 50 keys of 1 to 50 characters and 4096 bytes in JSON.
+func validAttributes(value interface{}) error {
+	attributes, _ := value.(*map[string]interface{})
+	if attributes == nil {
+		return nil
+	}
+	if len(*attributes) > 50 {
+		return errors.New("must have no more than 50 keys")
+	}
+	for k := range *attributes {
+		if k == "" || utf8.RuneCountInString(k) > 50 {
+			return errors.New("keys must be 1 to 50 characters")
+		}
+	}
+	b, err := json.Marshal(attributes)
+	if err != nil {
+		return err
+	}
+	if len(b) > 4096 {
+		return errors.New("must be no more than 4096 bytes in JSON")
+	}
+	return nil
+}
+
+func anySlice(ss []string) []interface{} {
+	rslts := []interface{}{}
+	for _, s := range ss {
+		rslts = append(rslts, s)
+	}
+	return rslts
+}
+
+// validateTagRename validate TagRename.
+func validateTagRename(from string, to string) error {
+	if to == "" {
+		return domain.Err400BadRequest.WithViolations(domain.Violation{Field: "to", Code: "required", Message: "cannot be blank"})
+	}
+	if from == to {
+		return domain.Err400BadRequest.WithViolations(domain.Violation{Field: "to", Code: "invalid", Message: "must be different from from"})
+	}
+	return nil
+}
+
+// validateTagMerge validate TagMerge, from without duplicates.
+func validateTagMerge(from []string, into string) error {
+	// open api
+	if len(from) == 0 {
+		return domain.Err400BadRequest.WithViolations(domain.Violation{Field: "from", Code: "required", Message: "cannot be blank"})
+	}
+	if into == "" {
+		return domain.Err400BadRequest.WithViolations(domain.Violation{Field: "into", Code: "required", Message: "cannot be blank"})
+	}
+	if domain.ContainsString(from, into) {
+		return domain.Err400BadRequest.WithViolations(domain.Violation{Field: "from", Code: "invalid", Message: "must not contain into"})
+	}
+	return nil
+}
+
+// validatePetSearch validate PetSearch.
+func validatePetSearch(s domain.PetSearch) error {
+	if len(s.Terms()) == 0 {
+		return domain.Err400BadRequest.WithViolations(domain.Violation{Field: "q", Code: "required", Message: "cannot be blank"})
+	}
+	return nil
+}
+
+// validateIdempotencyKey validate Idempotency-Key header.
+func validateIdempotencyKey(key string) error {
+	// open api
+	if key == "" {
+		return domain.Err400BadRequest.WithViolations(domain.Violation{Field: "Idempotency-Key", Code: "required", Message: "cannot be blank"})
+	}
+	if len(key) > 255 {
+		return domain.Err400BadRequest.WithViolations(domain.Violation{Field: "Idempotency-Key", Code: "max_length", Message: "the length must be no more than 255"})
 	}
 	return nil
 }
@@ -0,0 +1,79 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/opbls/scapo/petstore/domain"
+	"github.com/opbls/scapo/petstore/repository"
+	"github.com/stretchr/testify/assert"
+)
+
+// racedRepository is PetStoreRepository where another write lands before each of the first Races updates.
+type racedRepository struct {
+	repository.PetStoreRepository
+	Races   int
+	Updates int
+	pet     domain.VersionedPet
+}
+
+func (repo *racedRepository) QueryPet(ctx context.Context, id int) (*domain.VersionedPet, error) {
+	p := repo.pet
+	return &p, nil
+}
+
+func (repo *racedRepository) UpdatePet(ctx context.Context, p *domain.VersionedPet) (int, error) {
+	repo.Updates++
+	if repo.Updates <= repo.Races {
+		repo.pet.Version++
+		return -1, domain.Err412PreconditionFailed
+	}
+	if p.Version != 0 && p.Version != repo.pet.Version {
+		return -1, domain.Err412PreconditionFailed
+	}
+	repo.pet.Pet = p.Pet
+	repo.pet.Version++
+	return 1, nil
+}
+
+// noUnitOfWork runs fn without a transaction.
+type noUnitOfWork struct{}
+
+func (noUnitOfWork) Do(ctx context.Context, fn func(ctx context.Context) error) error {
+	return fn(ctx)
+}
+
+func TestPatchPet(t *testing.T) {
+	ctx := context.Background()
+	newRepo := func(races int) *racedRepository {
+		repo := &racedRepository{Races: races}
+		repo.pet.Id, repo.pet.Name, repo.pet.Version = 1, "foo", 1
+		return repo
+	}
+
+	t.Run("SUCCESS_PatchPet_Concurrent", func(t *testing.T) {
+		repo := newRepo(1)
+		rslt, err := NewPetStoreUsecase(noUnitOfWork{}, repo).PatchPet(ctx, 1, domain.PetPatch{"name": "bar"}, 0)
+		if assert.NoError(t, err) {
+			assert.Equal(t, "bar", rslt.Name)
+		}
+		assert.Equal(t, 2, repo.Updates)
+	})
+
+	// abnormal 409
+	t.Run("ABNORMAL_PatchPet_Concurrent", func(t *testing.T) {
+		repo := newRepo(100)
+		_, err := NewPetStoreUsecase(noUnitOfWork{}, repo).PatchPet(ctx, 1, domain.PetPatch{"name": "bar"}, 0)
+		assert.True(t, errors.Is(err, domain.Err409Conflict))
+		assert.Equal(t, 1+patchRetries, repo.Updates)
+	})
+
+	// abnormal 412
+	t.Run("ABNORMAL_PatchPet_IfMatch", func(t *testing.T) {
+		repo := newRepo(1)
+		_, err := NewPetStoreUsecase(noUnitOfWork{}, repo).PatchPet(ctx, 1, domain.PetPatch{"name": "bar"}, 1)
+		assert.True(t, errors.Is(err, domain.Err412PreconditionFailed))
+		assert.Equal(t, 1, repo.Updates)
+	})
+}
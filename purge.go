@@ -0,0 +1,42 @@
+package main
+
+import (
+	"context"
+	"time"
+
+	"github.com/opbls/scapo/logger"
+	"github.com/opbls/scapo/petstore/usecase"
+)
+
+// runPurge purges pets deleted for longer than retention every interval until ctx is done.
+// Disabled if interval is not positive.
+func runPurge(ctx context.Context, uc usecase.PetStoreUsecase, interval time.Duration, retention time.Duration) {
+	if interval <= 0 {
+		return
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		purgePets(ctx, uc, retention)
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// purgePets purges pets deleted before retention once, errors are logged to retry next time.
+func purgePets(ctx context.Context, uc usecase.PetStoreUsecase, retention time.Duration) {
+	i, err := uc.PurgePets(ctx, time.Now().Add(-retention))
+	if err != nil {
+		if ctx.Err() == nil {
+			logger.Error("error purging pets: ", err)
+		}
+		return
+	}
+	if i > 0 {
+		logger.Info("purged pets: ", i)
+	}
+}
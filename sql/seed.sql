@@ -0,0 +1,45 @@
+insert into petstore(name) values("name1");
+insert into petstore(name) values("name2");
+insert into petstore(name) values("name3");
+insert into petstore(name) values("name4");
+insert into petstore(name) values("name5");
+insert into petstore(name) values("name6");
+insert into petstore(name) values("name7");
+insert into petstore(name) values("name8");
+insert into petstore(name) values("name9");
+insert into petstore(name) values("name10");
+insert into petstore(name) values("name11");
+insert into petstore(name) values("name12");
+insert into petstore(name) values("name13");
+insert into petstore(name) values("name14");
+insert into petstore(name) values("name15");
+insert into petstore(name) values("name16");
+insert into petstore(name) values("name17");
+insert into petstore(name) values("name18");
+insert into petstore(name) values("name19");
+insert into petstore(name) values("name20");
+insert into tags(name) values("tag1");
+insert into tags(name) values("tag2");
+insert into tags(name) values("tag3");
+insert into tags(name) values("tag4");
+insert into tags(name) values("tag5");
+insert into pet_tags(pet_id, tag_id, position) values(1, 1, 0);
+insert into pet_tags(pet_id, tag_id, position) values(2, 2, 0);
+insert into pet_tags(pet_id, tag_id, position) values(3, 3, 0);
+insert into pet_tags(pet_id, tag_id, position) values(4, 4, 0);
+insert into pet_tags(pet_id, tag_id, position) values(5, 5, 0);
+insert into pet_tags(pet_id, tag_id, position) values(6, 1, 0);
+insert into pet_tags(pet_id, tag_id, position) values(7, 2, 0);
+insert into pet_tags(pet_id, tag_id, position) values(8, 3, 0);
+insert into pet_tags(pet_id, tag_id, position) values(9, 4, 0);
+insert into pet_tags(pet_id, tag_id, position) values(10, 5, 0);
+insert into pet_tags(pet_id, tag_id, position) values(11, 1, 0);
+insert into pet_tags(pet_id, tag_id, position) values(12, 2, 0);
+insert into pet_tags(pet_id, tag_id, position) values(13, 3, 0);
+insert into pet_tags(pet_id, tag_id, position) values(14, 4, 0);
+insert into pet_tags(pet_id, tag_id, position) values(15, 5, 0);
+insert into pet_tags(pet_id, tag_id, position) values(16, 1, 0);
+insert into pet_tags(pet_id, tag_id, position) values(17, 2, 0);
+insert into pet_tags(pet_id, tag_id, position) values(18, 3, 0);
+insert into pet_tags(pet_id, tag_id, position) values(19, 4, 0);
+insert into pet_tags(pet_id, tag_id, position) values(20, 5, 0);
@@ -1,26 +0,0 @@
-CREATE TABLE petstore(
-    id integer PRIMARY KEY autoincrement
-    , name text NOT NULL
-    , tag text
-);
-
-insert into petstore(name, tag) values("name1", "tag1");
-insert into petstore(name, tag) values("name2", "tag2");
-insert into petstore(name, tag) values("name3", "tag3");
-insert into petstore(name, tag) values("name4", "tag4");
-insert into petstore(name, tag) values("name5", "tag5");
-insert into petstore(name, tag) values("name6", "tag1");
-insert into petstore(name, tag) values("name7", "tag2");
-insert into petstore(name, tag) values("name8", "tag3");
-insert into petstore(name, tag) values("name9", "tag4");
-insert into petstore(name, tag) values("name10", "tag5");
-insert into petstore(name, tag) values("name11", "tag1");
-insert into petstore(name, tag) values("name12", "tag2");
-insert into petstore(name, tag) values("name13", "tag3");
-insert into petstore(name, tag) values("name14", "tag4");
-insert into petstore(name, tag) values("name15", "tag5");
-insert into petstore(name, tag) values("name16", "tag1");
-insert into petstore(name, tag) values("name17", "tag2");
-insert into petstore(name, tag) values("name18", "tag3");
-insert into petstore(name, tag) values("name19", "tag4");
-insert into petstore(name, tag) values("name20", "tag5");